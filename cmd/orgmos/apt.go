@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/huh"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/packages"
+	"orgmos/internal/ui"
+	"orgmos/internal/utils"
+)
+
+// runAptInstall instala una lista .lst con apt (Debian, Ubuntu y derivados)
+func runAptInstall(listDir string, configFile string, title string) {
+	fmt.Println(ui.Title(title))
+
+	// Clonar/actualizar dotfiles con spinner
+	if err := utils.CloneOrUpdateDotfilesWithSpinner(); err != nil {
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_failed", err)))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_fallback")))
+	}
+
+	// Cargar grupos de paquetes
+	var groups []packages.PackageGroup
+	var alternates []packages.Resolution
+	var installedMap map[string]bool
+	var parseErr error
+
+	// Spinner mientras verifica
+	ui.RunSpinner(i18n.T("common.checking_installed"), func() {
+		groups, alternates, parseErr = packages.LoadDistroList(listDir, "apt", configFile)
+		if parseErr != nil {
+			return
+		}
+
+		// Obtener todos los paquetes
+		var allPkgs []string
+		for _, g := range groups {
+			allPkgs = append(allPkgs, g.Packages...)
+		}
+
+		installedMap = packages.CheckInstalledApt(allPkgs)
+	})
+
+	if parseErr != nil {
+		fmt.Println(ui.Error(i18n.T("common.load_packages_failed", parseErr)))
+		return
+	}
+
+	if len(groups) == 0 {
+		fmt.Println(ui.Error(i18n.T("common.load_groups_failed")))
+		return
+	}
+
+	// Filtrar paquetes no instalados
+	var toInstall []string
+	for _, group := range groups {
+		for _, pkg := range group.Packages {
+			if !installedMap[pkg] {
+				toInstall = append(toInstall, pkg)
+			}
+		}
+	}
+
+	if len(toInstall) == 0 {
+		fmt.Println(ui.Success(i18n.T("common.all_installed")))
+		offerAlternates(alternates)
+		return
+	}
+
+	// Resolver versiones candidatas y detectar nombres inexistentes
+	toInstall = showAptCandidates(toInstall)
+	if len(toInstall) == 0 {
+		fmt.Println(ui.Warning(i18n.T("apt.none_available")))
+		return
+	}
+
+	// Confirmación final
+	var confirm bool
+	ui.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(i18n.T("common.will_install", len(toInstall))).
+				Affirmative(i18n.T("common.install")).
+				Negative(i18n.T("common.cancel")).
+				Value(&confirm),
+		),
+	).RunConfirm(&confirm)
+
+	if !confirm {
+		fmt.Println(ui.Warning(i18n.T("common.install_cancelled")))
+		return
+	}
+
+	if !prepareInstall("apt", toInstall) {
+		return
+	}
+
+	// Instalar paquetes
+	if err := packages.InstallApt(toInstall); err != nil {
+		fmt.Println(ui.Error(i18n.T("common.error", err)))
+		return
+	}
+
+	fmt.Println(ui.Success(i18n.T("common.install_done")))
+	offerAlternates(alternates)
+}
+
+// showAptCandidates muestra la versión que apt instalará para cada paquete y
+// advierte sobre los nombres que no existen en ningún repositorio habilitado.
+// Retorna solo los paquetes instalables.
+func showAptCandidates(toInstall []string) []string {
+	if !packages.AptIndexAvailable() {
+		// Sin índices locales no se puede validar; mostrar la lista tal cual
+		fmt.Println(ui.Info(i18n.T("common.to_install", len(toInstall))))
+		for _, pkg := range toInstall {
+			fmt.Println(ui.ListItem(pkg))
+		}
+		return toInstall
+	}
+
+	candidates, missing := packages.ResolveAptCandidates(toInstall)
+
+	var available []string
+	for _, pkg := range toInstall {
+		if _, ok := candidates[pkg]; ok {
+			available = append(available, pkg)
+		}
+	}
+
+	if len(available) > 0 {
+		fmt.Println(ui.Info(i18n.T("common.to_install", len(available))))
+		for _, pkg := range available {
+			candidate := candidates[pkg]
+			line := fmt.Sprintf("%s %s", pkg, candidate.Version)
+			if candidate.ProvidedBy != "" {
+				line += i18n.T("apt.provided_by", candidate.ProvidedBy)
+			}
+			if candidate.Description != "" {
+				line += " - " + candidate.Description
+			}
+			fmt.Println(ui.ListItem(line))
+		}
+	}
+
+	if len(missing) > 0 {
+		fmt.Println(ui.Warning(i18n.T("apt.unavailable", len(missing))))
+		for _, pkg := range missing {
+			fmt.Println(ui.ListItem(pkg))
+		}
+	}
+
+	return available
+}
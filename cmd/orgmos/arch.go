@@ -9,16 +9,18 @@ import (
 	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/packages"
 	"orgmos/internal/ui"
 	"orgmos/internal/utils"
 )
 
 var archCmd = &cobra.Command{
-	Use:   "arch",
-	Short: "Instalar herramientas de terminal para Arch",
-	Long:  `Instala fish, kitty, starship, eza, bat, fzf y otras herramientas modernas de terminal.`,
-	Run:   runArchInstall,
+	Use:         "arch",
+	Short:       i18n.Key("arch.short"),
+	Long:        i18n.Key("arch.long"),
+	Run:         runArchInstall,
+	Annotations: requiresArch,
 }
 
 func init() {
@@ -26,32 +28,32 @@ func init() {
 }
 
 func runArchInstall(cmd *cobra.Command, args []string) {
-	fmt.Println(ui.Title("Herramientas de Terminal - Arch Linux"))
+	fmt.Println(ui.Title(i18n.T("arch.title")))
 
 	// Clonar/actualizar dotfiles con spinner
 	if err := utils.CloneOrUpdateDotfilesWithSpinner(); err != nil {
-		fmt.Println(ui.Warning(fmt.Sprintf("No se pudo clonar/actualizar dotfiles: %v", err)))
-		fmt.Println(ui.Warning("Se intentará continuar con el repositorio existente si está disponible"))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_failed", err)))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_fallback")))
 	}
 
-	// Seleccionar instalador
-	var installer string
+	// Seleccionar instalador (sin terminal y con --yes se usa pacman)
+	installer := "pacman"
 	form := ui.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[string]().
-				Title("Selecciona el instalador").
-				Description("Elige el gestor de paquetes que deseas usar").
+				Title(i18n.T("common.select_installer")).
+				Description(i18n.T("common.select_installer_desc")).
 				Options(
-					huh.NewOption("pacman (solo repos oficiales)", "pacman"),
-					huh.NewOption("paru (AUR + repos oficiales)", "paru"),
-					huh.NewOption("yay (AUR + repos oficiales)", "yay"),
+					huh.NewOption(i18n.T("common.installer_pacman"), "pacman"),
+					huh.NewOption(i18n.T("common.installer_paru"), "paru"),
+					huh.NewOption(i18n.T("common.installer_yay"), "yay"),
 				).
 				Value(&installer),
 		),
 	)
 
-	if err := form.Run(); err != nil {
-		fmt.Println(ui.Warning("Instalación cancelada"))
+	if err := form.RunOrDefault(); err != nil {
+		fmt.Println(ui.Warning(i18n.T("common.install_cancelled")))
 		return
 	}
 
@@ -59,41 +61,41 @@ func runArchInstall(cmd *cobra.Command, args []string) {
 	if !packages.CheckInstallerAvailable(installer) {
 		if installer == "paru" {
 			// Intentar compilar paru
-			fmt.Println(ui.Info("Paru no está instalado. Intentando compilar..."))
+			fmt.Println(ui.Info(i18n.T("common.paru_building")))
 			if !packages.OfferInstallParu() {
 				// Si falla, ofrecer yay como fallback
-				fmt.Println(ui.Warning("No se pudo compilar paru. ¿Deseas usar yay como alternativa?"))
+				fmt.Println(ui.Warning(i18n.T("common.paru_build_failed")))
 				var useYay bool
 				form2 := ui.NewForm(
 					huh.NewGroup(
 						huh.NewConfirm().
-							Title("Usar yay como alternativa").
-							Description("yay puede instalar paquetes AUR y repos oficiales").
-							Affirmative("Sí, usar yay").
-							Negative("No, cancelar").
+							Title(i18n.T("common.use_yay")).
+							Description(i18n.T("common.use_yay_desc")).
+							Affirmative(i18n.T("common.use_yay_yes")).
+							Negative(i18n.T("common.no_cancel")).
 							Value(&useYay),
 					),
 				)
-				if err := form2.Run(); err != nil || !useYay {
-					fmt.Println(ui.Warning("Instalación cancelada"))
+				if err := form2.RunConfirm(&useYay); err != nil || !useYay {
+					fmt.Println(ui.Warning(i18n.T("common.install_cancelled")))
 					return
 				}
 				if !packages.CheckYayInstalled() {
-					fmt.Println(ui.Error("yay no está instalado. Instálalo primero con: yay -S yay"))
+					fmt.Println(ui.Error(i18n.T("common.yay_missing")))
 					return
 				}
 				installer = "yay"
 			}
 		} else {
-			fmt.Println(ui.Error(fmt.Sprintf("%s no está instalado. Instálalo primero.", installer)))
+			fmt.Println(ui.Error(i18n.T("common.installer_missing", installer)))
 			return
 		}
 	}
 
-	// Cargar paquetes desde LST
-	groups, err := packages.ParseLST("arch", "pkg_base.lst")
+	// Cargar paquetes desde LST (específica de Arch o compartida)
+	groups, alternates, err := packages.LoadDistroList("arch", "pacman", "pkg_base.lst")
 	if err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error cargando paquetes: %v", err)))
+		fmt.Println(ui.Error(i18n.T("common.load_packages_failed", err)))
 		return
 	}
 
@@ -104,7 +106,7 @@ func runArchInstall(cmd *cobra.Command, args []string) {
 	}
 
 	// Verificar paquetes instalados
-	fmt.Println(ui.Info("Verificando paquetes..."))
+	fmt.Println(ui.Info(i18n.T("common.checking_packages")))
 	installed := packages.CheckInstalledPacman(archPackages)
 
 	var toInstall []string
@@ -115,47 +117,36 @@ func runArchInstall(cmd *cobra.Command, args []string) {
 	}
 
 	if len(toInstall) == 0 {
-		fmt.Println(ui.Success("Todas las herramientas ya están instaladas"))
+		fmt.Println(ui.Success(i18n.T("arch.all_installed")))
+		offerAlternates(alternates)
 		offerFishShellSwitch()
 		return
 	}
 
-	// Crear opciones para multi-select (preseleccionadas)
-	var options []huh.Option[string]
-	finalSelection := make([]string, len(toInstall))
-	copy(finalSelection, toInstall) // Preseleccionar todos
-	for _, pkg := range toInstall {
-		options = append(options, huh.NewOption(pkg, pkg))
+	// Mostrar los paquetes agrupados por sección (preseleccionados)
+	finalSelection, err := pickPackages(i18n.T("common.select_packages"), groups, toInstall, "pacman")
+	if err != nil {
+		fmt.Println(ui.Warning(i18n.T("common.install_cancelled")))
+		return
 	}
 
-	// Mostrar lista multi-select preseleccionada
-	form3 := ui.NewForm(
-		huh.NewGroup(
-			huh.NewMultiSelect[string]().
-				Title(fmt.Sprintf("Selecciona paquetes a instalar (%d disponibles)", len(toInstall))).
-				Description("Todos los paquetes están preseleccionados. Deselecciona los que no deseas instalar.").
-				Options(options...).
-				Value(&finalSelection),
-		),
-	)
-
-	if err := form3.Run(); err != nil {
-		fmt.Println(ui.Warning("Instalación cancelada"))
+	if len(finalSelection) == 0 {
+		fmt.Println(ui.Warning(i18n.T("common.none_selected")))
 		return
 	}
 
-	if len(finalSelection) == 0 {
-		fmt.Println(ui.Warning("No se seleccionaron paquetes para instalar"))
+	if !prepareInstall("pacman", finalSelection) {
 		return
 	}
 
 	// Instalar todos los paquetes seleccionados en una sola corrida
 	if err := packages.InstallAllPackages(installer, finalSelection); err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error: %v", err)))
+		fmt.Println(ui.Error(i18n.T("common.error", err)))
 		return
 	}
 
-	fmt.Println(ui.Success("Herramientas instaladas correctamente"))
+	fmt.Println(ui.Success(i18n.T("arch.installed")))
+	offerAlternates(alternates)
 	offerFishShellSwitch()
 }
 
@@ -167,7 +158,7 @@ func offerFishShellSwitch() {
 
 	currentShell := os.Getenv("SHELL")
 	if currentShell == fishPath {
-		fmt.Println(ui.Dim("Fish ya es tu shell por defecto"))
+		fmt.Println(ui.Dim(i18n.T("arch.fish_already")))
 		return
 	}
 
@@ -175,26 +166,26 @@ func offerFishShellSwitch() {
 	form := ui.NewForm(
 		huh.NewGroup(
 			huh.NewConfirm().
-				Title("¿Deseas usar fish como shell por defecto?").
-				Description("Se ejecutarán los comandos recomendados para registrarlo y actualizar tu shell de login.").
-				Affirmative("Sí, cambiar").
-				Negative("No, luego").
+				Title(i18n.T("arch.fish_confirm")).
+				Description(i18n.T("arch.fish_confirm_desc")).
+				Affirmative(i18n.T("arch.fish_yes")).
+				Negative(i18n.T("arch.fish_no")).
 				Value(&confirm),
 		),
 	)
 
-	if err := form.Run(); err != nil || !confirm {
-		fmt.Println(ui.Dim("Puedes ejecutar 'chsh -s " + fishPath + "' más tarde."))
+	if err := form.RunConfirm(&confirm); err != nil || !confirm {
+		fmt.Println(ui.Dim(i18n.T("arch.fish_later", fishPath)))
 		return
 	}
 
 	ensureFishRegistered(fishPath)
 	if err := utils.RunCommand("chsh", "-s", fishPath); err != nil {
-		fmt.Println(ui.Error("No se pudo cambiar el shell automáticamente. Ejecuta: chsh -s " + fishPath))
+		fmt.Println(ui.Error(i18n.T("arch.fish_failed", fishPath)))
 		return
 	}
 
-	fmt.Println(ui.Success("Shell predeterminado actualizado a fish"))
+	fmt.Println(ui.Success(i18n.T("arch.fish_done")))
 }
 
 func ensureFishRegistered(fishPath string) {
@@ -208,6 +199,6 @@ func ensureFishRegistered(fishPath string) {
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
 	if err := cmd.Run(); err != nil {
-		fmt.Println(ui.Warning("No se pudo registrar fish en /etc/shells automáticamente"))
+		fmt.Println(ui.Warning(i18n.T("arch.fish_register_failed")))
 	}
 }
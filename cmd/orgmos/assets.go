@@ -3,19 +3,20 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 
 	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/ui"
+	"orgmos/internal/utils"
 )
 
 var assetsCmd = &cobra.Command{
 	Use:   "assets",
-	Short: "Descargar wallpapers",
-	Long:  `Clona el repositorio de wallpapers a ~/Pictures/Wallpapers`,
+	Short: i18n.Key("assets.short"),
+	Long:  i18n.Key("assets.long"),
 	Run:   runAssetsCopy,
 }
 
@@ -24,9 +25,9 @@ func init() {
 }
 
 func runAssetsCopy(cmd *cobra.Command, args []string) {
-	fmt.Println(ui.Title("Descargar Wallpapers"))
+	fmt.Println(ui.Title(i18n.T("assets.title")))
 
-	homeDir, _ := os.UserHomeDir()
+	homeDir, _ := utils.GetHomeDir()
 	wallpapersDest := filepath.Join(homeDir, "Pictures", "Wallpapers")
 
 	// Confirmación
@@ -34,51 +35,54 @@ func runAssetsCopy(cmd *cobra.Command, args []string) {
 	form := ui.NewForm(
 		huh.NewGroup(
 			huh.NewConfirm().
-				Title("Descargar wallpapers").
-				Description(fmt.Sprintf("Se clonará el repositorio de wallpapers en:\n%s", wallpapersDest)).
-				Affirmative("Descargar").
-				Negative("Cancelar").
+				Title(i18n.T("assets.short")).
+				Description(i18n.T("assets.confirm_desc", wallpapersDest)).
+				Affirmative(i18n.T("assets.download")).
+				Negative(i18n.T("common.cancel")).
 				Value(&confirm),
 		),
 	)
 
-	if err := form.Run(); err != nil || !confirm {
-		fmt.Println(ui.Warning("Descarga cancelada"))
+	if err := form.RunConfirm(&confirm); err != nil || !confirm {
+		fmt.Println(ui.Warning(i18n.T("assets.cancelled")))
 		return
 	}
 
 	// Crear directorio Pictures si no existe
-	os.MkdirAll(filepath.Join(homeDir, "Pictures"), 0755)
+	utils.MkdirAllForUser(filepath.Join(homeDir, "Pictures"), 0755)
 
 	repoURL := "https://github.com/osmargm1202/wallpapers.git"
 
+	gitCtx, cancel := utils.OperationContext(utils.Context(), utils.OpGit)
+	defer cancel()
+
 	// Si ya existe el directorio, hacer pull
 	if _, err := os.Stat(wallpapersDest); err == nil {
-		fmt.Println(ui.Info("Repositorio existente, actualizando..."))
+		fmt.Println(ui.Info(i18n.T("assets.updating")))
 		
-		pullCmd := exec.Command("git", "-C", wallpapersDest, "pull", "--ff-only")
+		pullCmd := utils.UserCommand(gitCtx, "git", "-C", wallpapersDest, "pull", "--ff-only")
 		pullCmd.Stdout = os.Stdout
 		pullCmd.Stderr = os.Stderr
 
 		if err := pullCmd.Run(); err != nil {
-			fmt.Println(ui.Warning("No se pudo actualizar. Intentando clonar de nuevo..."))
+			fmt.Println(ui.Warning(i18n.T("assets.update_failed")))
 			os.RemoveAll(wallpapersDest)
 		} else {
-			fmt.Println(ui.Success("Wallpapers actualizados correctamente"))
+			fmt.Println(ui.Success(i18n.T("assets.updated")))
 			return
 		}
 	}
 
 	// Clonar repositorio
-	fmt.Println(ui.Info("Clonando repositorio de wallpapers..."))
-	cloneCmd := exec.Command("git", "clone", "--depth=1", repoURL, wallpapersDest)
+	fmt.Println(ui.Info(i18n.T("assets.cloning")))
+	cloneCmd := utils.UserCommand(gitCtx, "git", "clone", "--depth=1", repoURL, wallpapersDest)
 	cloneCmd.Stdout = os.Stdout
 	cloneCmd.Stderr = os.Stderr
 
 	if err := cloneCmd.Run(); err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error clonando wallpapers: %v", err)))
+		fmt.Println(ui.Error(i18n.T("assets.clone_failed", err)))
 		return
 	}
 
-	fmt.Println(ui.Success("Wallpapers descargados correctamente"))
+	fmt.Println(ui.Success(i18n.T("assets.downloaded")))
 }
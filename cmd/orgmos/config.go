@@ -7,9 +7,9 @@ import (
 	"path/filepath"
 
 	"github.com/charmbracelet/huh"
-	"github.com/charmbracelet/huh/spinner"
 	"github.com/spf13/cobra"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/ui"
 	"orgmos/internal/utils"
 )
@@ -18,31 +18,28 @@ var noConfirm bool
 
 var configCmd = &cobra.Command{
 	Use:   "config",
-	Short: "Copiar configuraciones a ~/.config",
-	Long:  `Copia todas las configuraciones del repositorio a ~/.config`,
+	Short: i18n.Key("config.short"),
+	Long:  i18n.Key("config.long"),
 	Run:   runConfigCopy,
 }
 
 func init() {
-	configCmd.Flags().BoolVar(&noConfirm, "no-confirm", false, "No pedir confirmación antes de copiar los archivos")
+	configCmd.Flags().BoolVar(&noConfirm, "no-confirm", false, i18n.Key("config.flag_yes"))
 	rootCmd.AddCommand(configCmd)
 }
 
 func runConfigCopy(cmd *cobra.Command, args []string) {
-	fmt.Println(ui.Title("Copiar Configuraciones"))
+	fmt.Println(ui.Title(i18n.T("config.title")))
 
 	// Clonar o actualizar repositorio dotfiles con spinner
 	var cloneErr error
-	spinner.New().
-		Title("Clonando/actualizando repositorio dotfiles...").
-		Action(func() {
-			cloneErr = utils.CloneOrUpdateDotfiles()
-		}).
-		Run()
+	ui.RunSpinner(i18n.T("common.dotfiles_syncing"), func() {
+		cloneErr = utils.CloneOrUpdateDotfiles()
+	})
 
 	if cloneErr != nil {
-		fmt.Println(ui.Warning(fmt.Sprintf("No se pudo clonar/actualizar dotfiles: %v", cloneErr)))
-		fmt.Println(ui.Warning("Se intentará copiar desde el directorio existente si está disponible"))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_failed", cloneErr)))
+		fmt.Println(ui.Warning(i18n.T("config.fallback")))
 	}
 
 	// Obtener directorio dotfiles
@@ -50,11 +47,11 @@ func runConfigCopy(cmd *cobra.Command, args []string) {
 	configSource := filepath.Join(dotfilesDir, "config")
 
 	if _, err := os.Stat(configSource); os.IsNotExist(err) {
-		fmt.Println(ui.Error("Carpeta de configuraciones no encontrada en ~/Downloads/dotfiles/config"))
+		fmt.Println(ui.Error(i18n.T("config.not_found")))
 		return
 	}
 
-	homeDir, _ := os.UserHomeDir()
+	homeDir, _ := utils.GetHomeDir()
 	configDest := filepath.Join(homeDir, ".config")
 
 	// Contar archivos
@@ -72,16 +69,16 @@ func runConfigCopy(cmd *cobra.Command, args []string) {
 		form := ui.NewForm(
 			huh.NewGroup(
 				huh.NewConfirm().
-					Title(fmt.Sprintf("Se copiarán %d archivos a ~/.config", fileCount)).
-					Description("Los archivos existentes serán sobrescritos").
-					Affirmative("Copiar").
-					Negative("Cancelar").
+					Title(i18n.T("config.confirm", fileCount)).
+					Description(i18n.T("config.confirm_desc")).
+					Affirmative(i18n.T("config.copy")).
+					Negative(i18n.T("common.cancel")).
 					Value(&confirm),
 			),
 		)
 
-		if err := form.Run(); err != nil || !confirm {
-			fmt.Println(ui.Warning("Copia cancelada"))
+		if err := form.RunConfirm(&confirm); err != nil || !confirm {
+			fmt.Println(ui.Warning(i18n.T("config.cancelled")))
 			return
 		}
 	}
@@ -90,50 +87,48 @@ func runConfigCopy(cmd *cobra.Command, args []string) {
 	var copyErr error
 
 	// Copiar con spinner mostrando progreso
-	spinner.New().
-		Title("Copiando configuraciones...").
-		Action(func() {
-			copyErr = filepath.WalkDir(configSource, func(path string, d fs.DirEntry, err error) error {
-				if err != nil {
-					return nil
-				}
-
-				var relPath string
-				relPath, _ = filepath.Rel(configSource, path)
-				destPath := filepath.Join(configDest, relPath)
-
-				if d.IsDir() {
-					os.MkdirAll(destPath, 0755)
-					return nil
-				}
-
-				// Copiar archivo
-				data, err := os.ReadFile(path)
-				if err != nil {
-					failed++
-					return nil
-				}
-
-				os.MkdirAll(filepath.Dir(destPath), 0755)
-
-				if err := os.WriteFile(destPath, data, 0644); err != nil {
-					failed++
-					return nil
-				}
-
-				copied++
+	ui.RunSpinner(i18n.T("config.copying"), func() {
+		copyErr = filepath.WalkDir(configSource, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+
+			var relPath string
+			relPath, _ = filepath.Rel(configSource, path)
+			destPath := filepath.Join(configDest, relPath)
+
+			if d.IsDir() {
+				utils.MkdirAllForUser(destPath, 0755)
+				return nil
+			}
+
+			// Copiar archivo
+			data, err := os.ReadFile(path)
+			if err != nil {
+				failed++
 				return nil
-			})
-		}).
-		Run()
+			}
+
+			if err := utils.WriteFileForUser(destPath, data, 0644); err != nil {
+				failed++
+				return nil
+			}
+
+			copied++
+			return nil
+		})
+	})
 
 	if copyErr != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error: %v", copyErr)))
+		fmt.Println(ui.Error(i18n.T("common.error", copyErr)))
 		return
 	}
 
-	fmt.Println(ui.Success(fmt.Sprintf("Copiados: %d archivos", copied)))
+	fmt.Println(ui.Success(i18n.T("config.copied", copied)))
+	if err := utils.MarkConfigApplied(); err != nil {
+		fmt.Println(ui.Dim(i18n.T("config.stamp_failed", err)))
+	}
 	if failed > 0 {
-		fmt.Println(ui.Warning(fmt.Sprintf("Fallidos: %d archivos", failed)))
+		fmt.Println(ui.Warning(i18n.T("config.failed", failed)))
 	}
 }
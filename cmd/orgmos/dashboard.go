@@ -0,0 +1,673 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/packages"
+	"orgmos/internal/ui"
+	"orgmos/internal/utils"
+)
+
+// maxLogLines es cuántas líneas conserva el registro del panel
+const maxLogLines = 5000
+
+// sidebarWidth es el ancho de la lista de acciones, con borde
+const sidebarWidth = 36
+
+// dashboardAction es una acción que se puede encolar desde el panel. Se
+// ejecuta como un proceso aparte de orgmos con los argumentos de Args.
+type dashboardAction struct {
+	Group      string // Encabezado bajo el que se muestra
+	Title      string
+	Args       []string
+	Privileged bool // Pide la contraseña antes de empezar
+}
+
+// unprivilegedActions son las acciones integradas que no usan sudo: Flatpak
+// instala a través de polkit y los scripts van al directorio del usuario
+var unprivilegedActions = map[string]bool{
+	"flatpak": true,
+	"scripts": true,
+}
+
+// jobState es el estado de una acción encolada
+type jobState int
+
+const (
+	jobPending jobState = iota
+	jobRunning
+	jobDone
+	jobFailed
+)
+
+// dashboardJob es una acción en la cola
+type dashboardJob struct {
+	action      dashboardAction
+	state       jobState
+	interactive bool // Corre en la terminal, con las preguntas de siempre
+}
+
+// dashboardStatus es la información de la cabecera
+type dashboardStatus struct {
+	loaded       bool
+	distro       string
+	revision     string
+	date         string
+	updates      int
+	updatesKnown bool
+}
+
+// Mensajes del panel
+type (
+	jobOutputMsg   string // Una línea de la salida de la acción en curso
+	jobFinishedMsg struct {
+		failed  bool
+		err     error // Error al lanzar la acción, si lo hubo
+		elapsed time.Duration
+	}
+	// privilegesMsg indica si hace falta pedir la contraseña antes de la acción en curso
+	privilegesMsg struct {
+		cached bool
+	}
+	// privilegesDoneMsg es el resultado de pedir la contraseña en la terminal
+	privilegesDoneMsg struct {
+		err error
+	}
+	dashboardStatusMsg dashboardStatus
+)
+
+// dashboardModel es el modelo bubbletea del panel de 'orgmos menu'
+type dashboardModel struct {
+	program *tea.Program
+
+	actions []dashboardAction
+	cursor  int
+	offset  int // Primera fila visible de la barra lateral
+
+	queue []dashboardJob
+
+	lines  []string
+	log    viewport.Model
+	follow bool // Mostrar siempre el final del registro
+
+	status      dashboardStatus
+	notice      string
+	confirmQuit bool
+	choosing    bool // Esperando cómo ejecutar la acción seleccionada
+
+	width  int
+	height int
+}
+
+// dashboardActions arma la lista de acciones: las opciones de la distro
+// detectada seguidas de las comunes, sin las que no funcionan en este sistema
+func dashboardActions() []dashboardAction {
+	var actions []dashboardAction
+	for _, d := range menuDescriptors() {
+		for _, entry := range availableEntries(d) {
+			action := dashboardAction{Group: d.Title, Title: entry.Title}
+			if entry.Action != "" {
+				command, ok := menuActionCommands[entry.Action]
+				if !ok {
+					continue
+				}
+				action.Args = []string{command}
+				action.Privileged = !unprivilegedActions[entry.Action]
+			} else {
+				action.Args = []string{d.Name, entry.List}
+				action.Privileged = true
+			}
+			actions = append(actions, action)
+		}
+	}
+
+	general := i18n.T("dashboard.general")
+	if actionAvailable("flatpak") {
+		actions = append(actions, dashboardAction{Group: general, Title: "Flatpak", Args: []string{"flatpak"}})
+	}
+	return append(actions,
+		dashboardAction{Group: general, Title: i18n.T("common.scripts"), Args: []string{"scripts"}},
+		dashboardAction{Group: general, Title: i18n.T("menu.config"), Args: []string{"config"}},
+		dashboardAction{Group: general, Title: i18n.T("menu.assets"), Args: []string{"assets"}},
+	)
+}
+
+// runDashboard muestra el panel a pantalla completa hasta que el usuario sale
+func runDashboard() error {
+	model := &dashboardModel{
+		actions: dashboardActions(),
+		follow:  true,
+		log:     viewport.New(0, 0),
+	}
+	model.log.KeyMap = viewport.KeyMap{
+		PageDown:     key.NewBinding(key.WithKeys("pgdown")),
+		PageUp:       key.NewBinding(key.WithKeys("pgup")),
+		HalfPageDown: key.NewBinding(key.WithKeys("ctrl+d")),
+		HalfPageUp:   key.NewBinding(key.WithKeys("ctrl+u")),
+		Left:         key.NewBinding(key.WithKeys("left")),
+		Right:        key.NewBinding(key.WithKeys("right")),
+	}
+
+	// Las señales las atiende utils.HandleSignals; al cancelar se restaura la terminal
+	model.program = tea.NewProgram(model, tea.WithAltScreen(), tea.WithoutSignalHandler())
+	removeCleanup := utils.OnCancel(func() {
+		model.program.Kill()
+		model.program.Wait()
+	})
+	defer removeCleanup()
+
+	_, err := model.program.Run()
+	if utils.Context().Err() != nil {
+		// La cancelación sigue en utils.HandleSignals, que termina el proceso
+		select {}
+	}
+	return err
+}
+
+func (m *dashboardModel) Init() tea.Cmd {
+	return loadDashboardStatus
+}
+
+// loadDashboardStatus consulta la distro, la revisión de dotfiles y las actualizaciones pendientes
+func loadDashboardStatus() tea.Msg {
+	status := dashboardStatus{loaded: true, distro: systemName()}
+	manager := systemPackageManager()
+
+	if revision, date, err := utils.GetDotfilesRevision(); err == nil {
+		status.revision = revision
+		status.date = date
+	}
+
+	if pending, err := packages.PendingUpdates(manager); err == nil {
+		status.updates = pending
+		status.updatesKnown = true
+	}
+	return dashboardStatusMsg(status)
+}
+
+func (m *dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.resize()
+		return m, nil
+
+	case dashboardStatusMsg:
+		m.status = dashboardStatus(msg)
+		return m, nil
+
+	case jobOutputMsg:
+		m.appendLog(string(msg))
+		return m, nil
+
+	case privilegesMsg:
+		if msg.cached {
+			return m, m.runJob()
+		}
+		// La contraseña se pide en la terminal, con el panel suspendido
+		return m, tea.Exec(terminalStep(utils.EnsurePrivileges), func(err error) tea.Msg {
+			return privilegesDoneMsg{err: err}
+		})
+
+	case privilegesDoneMsg:
+		if msg.err != nil {
+			return m.Update(jobFinishedMsg{failed: true, err: msg.err})
+		}
+		return m, m.runJob()
+
+	case jobFinishedMsg:
+		if msg.err != nil {
+			m.appendLog(ui.Error(msg.err.Error()))
+		}
+		for i := range m.queue {
+			if m.queue[i].state == jobRunning {
+				m.queue[i].state = jobDone
+				if msg.failed {
+					m.queue[i].state = jobFailed
+				}
+			}
+		}
+		elapsed := msg.elapsed.Round(time.Second).String()
+		if msg.failed {
+			m.appendLog(ui.Error(i18n.T("dashboard.finished_errors", elapsed)))
+		} else {
+			m.appendLog(ui.Success(i18n.T("dashboard.finished", elapsed)))
+		}
+		return m, tea.Batch(m.startNext(), loadDashboardStatus)
+
+	case tea.KeyMsg:
+		return m, m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+// handleKey atiende las teclas del panel
+func (m *dashboardModel) handleKey(msg tea.KeyMsg) tea.Cmd {
+	if m.choosing && msg.String() != "ctrl+c" {
+		return m.chooseRun(msg.String())
+	}
+	if msg.String() != "q" && msg.String() != "ctrl+c" {
+		m.confirmQuit = false
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		if !m.busy() {
+			return tea.Quit
+		}
+		if !m.confirmQuit {
+			m.confirmQuit = true
+			m.notice = ui.Warning(i18n.T("dashboard.confirm_quit"))
+			return nil
+		}
+		// Cancelar como con Ctrl+C fuera del panel: SIGINT a todo el grupo de
+		// procesos detiene los hijos, ejecuta las limpiezas y restaura la terminal
+		m.notice = ui.Warning(i18n.T("dashboard.cancelling"))
+		syscall.Kill(0, syscall.SIGINT)
+		return nil
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.actions)-1 {
+			m.cursor++
+		}
+	case "home":
+		m.cursor = 0
+	case "end", "G":
+		m.follow = true
+		m.log.GotoBottom()
+	case "enter", " ":
+		if len(m.actions) == 0 {
+			return nil
+		}
+		m.choosing = true
+		m.notice = ui.Warning(i18n.T("dashboard.choose_run", m.actions[m.cursor].Title))
+		return nil
+	case "c":
+		// Quitar de la cola lo terminado y lo que aún no empezó
+		var kept []dashboardJob
+		for _, job := range m.queue {
+			if job.state == jobRunning {
+				kept = append(kept, job)
+			}
+		}
+		m.queue = kept
+		m.notice = ""
+		m.resize()
+	default:
+		var cmd tea.Cmd
+		m.log, cmd = m.log.Update(msg)
+		m.follow = m.log.AtBottom()
+		return cmd
+	}
+
+	m.scroll()
+	return nil
+}
+
+// chooseRun encola la acción seleccionada según la tecla: y la ejecuta en
+// segundo plano aceptando las opciones marcadas, como con --yes, e la ejecuta
+// en la terminal con las preguntas de siempre; cualquier otra tecla la descarta
+func (m *dashboardModel) chooseRun(key string) tea.Cmd {
+	m.choosing = false
+	action := m.actions[m.cursor]
+
+	switch key {
+	case "y", "s":
+		m.queue = append(m.queue, dashboardJob{action: action})
+	case "i":
+		m.queue = append(m.queue, dashboardJob{action: action, interactive: true})
+	default:
+		m.notice = ""
+		return nil
+	}
+
+	m.notice = ui.Info(i18n.T("dashboard.queued", action.Title))
+	m.resize()
+	return m.startNext()
+}
+
+// busy indica si hay una acción en curso o esperando en la cola
+func (m *dashboardModel) busy() bool {
+	for _, job := range m.queue {
+		if job.state == jobRunning || job.state == jobPending {
+			return true
+		}
+	}
+	return false
+}
+
+// startNext empieza la siguiente acción de la cola si no hay otra en curso
+func (m *dashboardModel) startNext() tea.Cmd {
+	next := m.current()
+	if next >= 0 {
+		return nil
+	}
+	for i, job := range m.queue {
+		if job.state == jobPending {
+			next = i
+			break
+		}
+	}
+	if next < 0 {
+		return nil
+	}
+
+	job := &m.queue[next]
+	job.state = jobRunning
+	if len(m.lines) > 0 {
+		m.appendLog("")
+	}
+	header := i18n.T("dashboard.job_header", job.action.Group, job.action.Title)
+	m.appendLog(ui.HighlightStyle.Render(header))
+
+	if job.interactive {
+		m.appendLog(ui.Dim(i18n.T("dashboard.in_terminal")))
+		return m.runInTerminal(header, job.action)
+	}
+
+	m.appendLog(ui.Dim("$ orgmos " + strings.Join(jobArgs(job.action), " ")))
+	if !job.action.Privileged {
+		return m.runJob()
+	}
+	return func() tea.Msg {
+		return privilegesMsg{cached: utils.PrivilegesCached()}
+	}
+}
+
+// current retorna la posición en la cola de la acción en curso, o -1
+func (m *dashboardModel) current() int {
+	for i, job := range m.queue {
+		if job.state == jobRunning {
+			return i
+		}
+	}
+	return -1
+}
+
+// jobArgs son los argumentos del proceso que ejecuta una acción en segundo
+// plano: sin terminal donde preguntar, las opciones se aceptan con --yes
+func jobArgs(action dashboardAction) []string {
+	return append(append([]string(nil), action.Args...), "--yes")
+}
+
+// runJob ejecuta la acción en curso en otro proceso de orgmos, fuera del hilo
+// del panel; su salida llega línea por línea al registro
+func (m *dashboardModel) runJob() tea.Cmd {
+	action := m.queue[m.current()].action
+	return func() tea.Msg {
+		exe, err := os.Executable()
+		if err != nil {
+			return jobFinishedMsg{failed: true, err: err}
+		}
+
+		out := &logWriter{program: m.program}
+		start := time.Now()
+		_, err = utils.RunCommandOutput(out, exe, jobArgs(action)...)
+		out.Flush()
+		return jobFinishedMsg{failed: err != nil, elapsed: time.Since(start)}
+	}
+}
+
+// runInTerminal ejecuta la acción con el panel suspendido y la terminal a su
+// disposición: los selectores, las confirmaciones y la contraseña se piden como
+// fuera del panel. Al terminar se espera Enter para que se pueda leer la salida.
+func (m *dashboardModel) runInTerminal(header string, action dashboardAction) tea.Cmd {
+	var failed bool
+	var elapsed time.Duration
+
+	step := func() error {
+		exe, err := os.Executable()
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(ui.Title(header))
+		start := time.Now()
+		err = utils.RunCommandTerminal(exe, action.Args...)
+		failed = err != nil
+		elapsed = time.Since(start)
+
+		fmt.Println()
+		fmt.Print(ui.Dim(i18n.T("dashboard.press_enter")))
+		bufio.NewReader(os.Stdin).ReadString('\n')
+		return nil
+	}
+
+	return tea.Exec(terminalStep(step), func(err error) tea.Msg {
+		return jobFinishedMsg{failed: failed || err != nil, err: err, elapsed: elapsed}
+	})
+}
+
+// terminalStep es un paso que necesita la terminal, como pedir la contraseña:
+// bubbletea suspende el panel mientras corre
+type terminalStep func() error
+
+func (s terminalStep) Run() error        { return s() }
+func (terminalStep) SetStdin(io.Reader)  {}
+func (terminalStep) SetStdout(io.Writer) {}
+func (terminalStep) SetStderr(io.Writer) {}
+
+// logWriter manda al panel cada línea que escribe la acción en curso. Un
+// retorno de carro descarta lo escrito antes en la línea, como en la terminal.
+type logWriter struct {
+	program *tea.Program
+	line    []byte
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		switch b {
+		case '\n':
+			w.send()
+		case '\r':
+			w.line = w.line[:0]
+		default:
+			w.line = append(w.line, b)
+		}
+	}
+	return len(p), nil
+}
+
+// Flush manda la línea pendiente, si la hay
+func (w *logWriter) Flush() {
+	if len(bytes.TrimSpace(w.line)) > 0 {
+		w.send()
+	}
+}
+
+// send manda la línea al panel y empieza una nueva
+func (w *logWriter) send() {
+	w.program.Send(jobOutputMsg(w.line))
+	w.line = w.line[:0]
+}
+
+// appendLog agrega texto al registro
+func (m *dashboardModel) appendLog(text string) {
+	m.lines = append(m.lines, strings.Split(strings.TrimRight(text, "\n"), "\n")...)
+
+	if len(m.lines) > maxLogLines {
+		m.lines = m.lines[len(m.lines)-maxLogLines:]
+	}
+
+	m.log.SetContent(strings.Join(m.lines, "\n"))
+	if m.follow {
+		m.log.GotoBottom()
+	}
+}
+
+// queueHeight es cuántas líneas ocupa la cola dentro de su recuadro
+func (m *dashboardModel) queueHeight() int {
+	return min(max(len(m.queue), 1), 6) + 1
+}
+
+// resize ajusta el registro al tamaño de la ventana
+func (m *dashboardModel) resize() {
+	// Cabecera (2), pie (2) y los bordes de la cola y del registro (4)
+	m.log.Width = max(m.width-sidebarWidth-2, 10)
+	m.log.Height = max(m.height-4-4-m.queueHeight(), 3)
+	if m.follow {
+		m.log.GotoBottom()
+	}
+	m.scroll()
+}
+
+// sidebarRows construye las filas de la barra lateral intercalando los
+// encabezados; retorna también la fila del cursor
+func (m *dashboardModel) sidebarRows() ([]string, int) {
+	var rows []string
+	cursorRow := 0
+	width := sidebarWidth - 4
+
+	lastGroup := "\x00"
+	for i, action := range m.actions {
+		if action.Group != lastGroup {
+			rows = append(rows, ui.HighlightStyle.MaxWidth(width).Render(action.Group))
+			lastGroup = action.Group
+		}
+
+		title := lipgloss.NewStyle().MaxWidth(width - 2).Render(action.Title)
+		if i == m.cursor {
+			cursorRow = len(rows)
+			rows = append(rows, ui.SuccessStyle.Render("> "+title))
+		} else {
+			rows = append(rows, "  "+title)
+		}
+	}
+	return rows, cursorRow
+}
+
+// sidebarHeight es cuántas filas de acciones caben en la barra lateral
+func (m *dashboardModel) sidebarHeight() int {
+	return max(m.height-4-2, 3)
+}
+
+// scroll mantiene el cursor (y su encabezado) dentro de la barra lateral
+func (m *dashboardModel) scroll() {
+	rows, cursorRow := m.sidebarRows()
+	height := m.sidebarHeight()
+
+	if cursorRow-1 < m.offset {
+		m.offset = max(cursorRow-1, 0)
+	}
+	if cursorRow >= m.offset+height {
+		m.offset = cursorRow - height + 1
+	}
+	m.offset = max(min(m.offset, len(rows)-height), 0)
+}
+
+func (m *dashboardModel) View() string {
+	if m.width == 0 {
+		return ""
+	}
+
+	border := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ui.Gray).
+		Padding(0, 1)
+
+	rows, _ := m.sidebarRows()
+	height := m.sidebarHeight()
+	end := min(m.offset+height, len(rows))
+	sidebar := border.
+		Width(sidebarWidth - 2).
+		Height(height).
+		Render(strings.Join(rows[m.offset:end], "\n"))
+
+	right := lipgloss.JoinVertical(lipgloss.Left,
+		border.Padding(0).Width(m.log.Width).Render(m.queueView()),
+		border.Padding(0).Width(m.log.Width).Render(m.log.View()),
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		m.headerView(),
+		lipgloss.JoinHorizontal(lipgloss.Top, sidebar, right),
+		m.footerView(),
+	)
+}
+
+// headerView muestra el título y el estado del sistema
+func (m *dashboardModel) headerView() string {
+	title := ui.HighlightStyle.Render(i18n.T("menu.title")) + ui.DimStyle.Render("  "+i18n.T("menu.version", Version))
+
+	var status string
+	switch {
+	case !m.status.loaded:
+		status = ui.DimStyle.Render(i18n.T("dashboard.loading"))
+	default:
+		parts := []string{i18n.T("dashboard.status_distro", m.status.distro)}
+		if m.status.revision != "" {
+			parts = append(parts, i18n.T("dashboard.status_dotfiles", m.status.revision, m.status.date))
+		} else {
+			parts = append(parts, i18n.T("dashboard.status_no_dotfiles"))
+		}
+		if m.status.updatesKnown {
+			parts = append(parts, i18n.T("dashboard.status_updates", m.status.updates))
+		} else {
+			parts = append(parts, i18n.T("dashboard.status_updates_unknown"))
+		}
+		status = ui.DimStyle.Render(strings.Join(parts, "  ·  "))
+	}
+
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(title + "\n" + status)
+}
+
+// queueView muestra la cola con el estado de cada acción
+func (m *dashboardModel) queueView() string {
+	title := ui.HighlightStyle.Render(i18n.T("dashboard.queue"))
+	if len(m.queue) == 0 {
+		return title + "\n" + ui.DimStyle.Render(i18n.T("dashboard.queue_empty"))
+	}
+
+	// Mostrar las últimas, que incluyen la que está en curso y las pendientes
+	jobs := m.queue
+	if limit := m.queueHeight() - 1; len(jobs) > limit {
+		start := len(jobs) - limit
+		for i, job := range jobs {
+			if job.state == jobRunning || job.state == jobPending {
+				start = min(i, start)
+				break
+			}
+		}
+		jobs = jobs[start : start+limit]
+	}
+
+	lines := []string{title}
+	for _, job := range jobs {
+		name := job.action.Group + " · " + job.action.Title
+		switch job.state {
+		case jobPending:
+			lines = append(lines, ui.DimStyle.Render("  "+i18n.T("dashboard.job_pending", name)))
+		case jobRunning:
+			lines = append(lines, ui.InfoStyle.Render("▶ "+i18n.T("dashboard.job_running", name)))
+		case jobDone:
+			lines = append(lines, ui.SuccessStyle.Render("✓ "+name))
+		case jobFailed:
+			lines = append(lines, ui.ErrorStyle.Render("✗ "+i18n.T("dashboard.job_failed", name)))
+		}
+	}
+	return lipgloss.NewStyle().MaxWidth(m.log.Width).Render(strings.Join(lines, "\n"))
+}
+
+// footerView muestra el aviso más reciente y las teclas disponibles
+func (m *dashboardModel) footerView() string {
+	style := lipgloss.NewStyle().MaxWidth(m.width)
+	return style.Render(m.notice) + "\n" + style.Render(ui.DimStyle.Render(i18n.T("dashboard.help")))
+}
@@ -0,0 +1,356 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+
+	"orgmos/internal/distro"
+	"orgmos/internal/i18n"
+	"orgmos/internal/ui"
+	"orgmos/internal/utils"
+)
+
+// distroDescriptors contiene los descriptores cargados al iniciar orgmos
+var distroDescriptors []distro.Descriptor
+
+// systemDistro es el descriptor de la distro en ejecución, detectado una sola vez
+// al cargar los descriptores; systemDetected es false si ninguno coincide
+var (
+	systemDistro   distro.Descriptor
+	systemDetected bool
+)
+
+// distroLoadErr es el error al cargar los descriptores; se informa en initConfig,
+// cuando ya se conoce el modo de salida
+var distroLoadErr error
+
+// menuActions son las acciones integradas que un descriptor puede usar en su menú
+var menuActions = map[string]func(){
+	"terminal": func() { runArchInstall(nil, nil) },
+	"network":  func() { runNetworkInstall(nil, nil) },
+	"niri":     func() { runNiriInstall(nil, nil) },
+	"i3":       func() { runI3Install(nil, nil) },
+	"paru":     func() { runParuInstall(nil, nil) },
+	"flatpak":  func() { runFlatpakInstall(nil, nil) },
+	"scripts":  func() { runScriptsInstall(nil, nil) },
+}
+
+// menuActionCommands son los subcomandos que ejecutan cada acción integrada,
+// ej: para correrla desde el panel en un proceso aparte
+var menuActionCommands = map[string]string{
+	"terminal": "arch",
+	"network":  "network",
+	"niri":     "niri",
+	"i3":       "i3",
+	"paru":     "paru",
+	"flatpak":  "flatpak",
+	"scripts":  "scripts",
+}
+
+// archActions son las acciones que solo funcionan en Arch y derivados: usan
+// pacman, paru o el AUR
+var archActions = map[string]bool{
+	"terminal": true,
+	"network":  true,
+	"niri":     true,
+	"i3":       true,
+	"paru":     true,
+}
+
+// distroAnnotation marca los comandos que solo funcionan en una distro; el valor
+// es el nombre de su descriptor
+const distroAnnotation = "orgmos/distro"
+
+// requiresArch es la anotación de los comandos exclusivos de Arch
+var requiresArch = map[string]string{distroAnnotation: "arch"}
+
+// generatedCommand es un subcomando generado a partir de un descriptor. Sus
+// textos de ayuda se fijan en localizeDistroCommands, ya con el idioma del perfil.
+type generatedCommand struct {
+	cmd    *cobra.Command
+	distro string // Nombre del descriptor
+	list   string // Command de la lista; vacío para el comando de la distro
+}
+
+// generatedCommands son los subcomandos creados por registerDistroCommands
+var generatedCommands []generatedCommand
+
+// registerDistroCommands genera los subcomandos de cada distro a partir de sus descriptores
+// y de las listas .lst descubiertas en el repo dotfiles. Si ya existe un comando con el
+// nombre de la distro (ej: "arch"), las listas se agregan a él.
+func registerDistroCommands() {
+	// Los comandos se registran antes de leer las flags: el aviso queda para después
+	descriptors, err := distro.Load()
+	distroLoadErr = err
+	distroDescriptors = descriptors
+	systemDistro, systemDetected = distro.Detect(descriptors)
+
+	for _, d := range descriptors {
+		parent := findSubcommand(rootCmd, d.Name)
+		if parent == nil {
+			parent = &cobra.Command{Use: d.Name}
+			rootCmd.AddCommand(parent)
+			generatedCommands = append(generatedCommands, generatedCommand{cmd: parent, distro: d.Name})
+		}
+
+		addListCommands(parent, d)
+	}
+
+	// "orgmos install <lista>" expone las listas de la distro detectada
+	if d, ok := detectedDistro(); ok {
+		addListCommands(installCmd, d)
+	}
+}
+
+// addListCommands agrega un subcomando por cada lista del descriptor
+func addListCommands(parent *cobra.Command, d distro.Descriptor) {
+	for _, l := range d.Lists {
+		if findSubcommand(parent, l.Command) != nil {
+			continue
+		}
+
+		// El descriptor se busca al ejecutar para usar los textos ya traducidos
+		name, command := d.Name, l.Command
+		cmd := &cobra.Command{
+			Use:         command,
+			Annotations: map[string]string{distroAnnotation: name},
+			Run: func(cmd *cobra.Command, args []string) {
+				desc, _ := findDescriptor(name)
+				list, _ := desc.FindList(command)
+				runDistroList(desc, list)
+			},
+		}
+		parent.AddCommand(cmd)
+		generatedCommands = append(generatedCommands, generatedCommand{cmd: cmd, distro: name, list: command})
+	}
+}
+
+// localizeDistroCommands traduce los descriptores integrados y fija los textos
+// de ayuda de los comandos generados a partir de ellos
+func localizeDistroCommands() {
+	for i := range distroDescriptors {
+		distroDescriptors[i].Localize()
+	}
+	systemDistro.Localize()
+
+	for _, g := range generatedCommands {
+		d, ok := findDescriptor(g.distro)
+		if !ok {
+			continue
+		}
+		if g.list == "" {
+			g.cmd.Short = i18n.T("distro.short", d.Title)
+			g.cmd.Long = i18n.T("distro.long", d.Title)
+			continue
+		}
+
+		l, _ := d.FindList(g.list)
+		short := l.Description
+		if short == "" {
+			short = i18n.T("distro.list_short", l.Title)
+		}
+		g.cmd.Short = fmt.Sprintf("%s (%s)", short, d.Title)
+		g.cmd.Long = i18n.T("distro.list_long", d.Dir(), l.File)
+	}
+}
+
+// findSubcommand busca un subcomando directo por nombre
+func findSubcommand(parent *cobra.Command, name string) *cobra.Command {
+	for _, c := range parent.Commands() {
+		if c.Name() == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// findDescriptor busca un descriptor cargado por nombre
+func findDescriptor(name string) (distro.Descriptor, bool) {
+	for _, d := range distroDescriptors {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return distro.Descriptor{}, false
+}
+
+// detectedDistro retorna el descriptor de la distro en ejecución. Todo lo que
+// depende de la distro pasa por aquí para que no haya dos detecciones distintas.
+func detectedDistro() (distro.Descriptor, bool) {
+	return systemDistro, systemDetected
+}
+
+// systemPackageManager retorna el gestor de paquetes del sistema: el del
+// descriptor detectado o, si ninguno coincide, el de utils.GetPackageManager
+func systemPackageManager() string {
+	if d, ok := detectedDistro(); ok {
+		return d.PackageManager
+	}
+	return utils.GetPackageManager()
+}
+
+// systemName retorna el nombre legible de la distro en ejecución
+func systemName() string {
+	if d, ok := detectedDistro(); ok {
+		return d.Title
+	}
+	if utils.DetectOS() != utils.DistroUnknown {
+		return utils.GetDistroName()
+	}
+	return i18n.T("distro.unknown_system")
+}
+
+// distroSupported indica si lo del descriptor name funciona en este sistema:
+// basta con que use el mismo gestor de paquetes. Si no se reconoce la distro,
+// se acepta cuando ese gestor está instalado.
+func distroSupported(name string) bool {
+	d, ok := findDescriptor(name)
+	if !ok {
+		return true
+	}
+
+	manager := systemPackageManager()
+	if manager == "unknown" {
+		return utils.CommandExists(d.PackageManager)
+	}
+	return manager == d.PackageManager
+}
+
+// requireDistro rechaza los comandos marcados con distroAnnotation cuando se
+// ejecutan en otra distro, antes de que lleguen a llamar a su gestor de paquetes
+func requireDistro(cmd *cobra.Command, args []string) error {
+	name := cmd.Annotations[distroAnnotation]
+	if name == "" || distroSupported(name) {
+		return nil
+	}
+
+	title := name
+	if d, ok := findDescriptor(name); ok {
+		title = d.Title
+	}
+	err := fmt.Errorf(i18n.T("distro.wrong_distro"), cmd.CommandPath(), title, systemName())
+	fmt.Println(ui.Error(err.Error()))
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return err
+}
+
+// actionAvailable indica si una acción del menú puede funcionar en este sistema
+func actionAvailable(action string) bool {
+	switch {
+	case archActions[action]:
+		return distroSupported("arch")
+	case action == "flatpak":
+		// Fuera de Arch no se puede instalar flatpak desde el AUR
+		return utils.CommandExists("flatpak") || distroSupported("arch")
+	}
+	return true
+}
+
+// menuDescriptors retorna las distros que ofrece el menú: la detectada o, si no
+// se reconoce el sistema, las que usan un gestor de paquetes instalado
+func menuDescriptors() []distro.Descriptor {
+	if d, ok := detectedDistro(); ok {
+		return []distro.Descriptor{d}
+	}
+
+	var descriptors []distro.Descriptor
+	for _, d := range distroDescriptors {
+		if distroSupported(d.Name) {
+			descriptors = append(descriptors, d)
+		}
+	}
+	return descriptors
+}
+
+// availableEntries retorna las opciones del menú de d que funcionan en este sistema
+func availableEntries(d distro.Descriptor) []distro.MenuEntry {
+	var entries []distro.MenuEntry
+	for _, entry := range d.MenuEntries() {
+		if entry.Action == "" || actionAvailable(entry.Action) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// runDistroList instala una lista usando el flujo del gestor de paquetes de la distro
+func runDistroList(d distro.Descriptor, l distro.List) {
+	title := fmt.Sprintf("%s - %s", l.Title, d.Title)
+
+	switch d.PackageManager {
+	case "pacman":
+		runPacmanInstall(d.Dir(), l.File, title)
+	case "apt":
+		runAptInstall(d.Dir(), l.File, title)
+	case "dnf":
+		runDnfInstall(d.Dir(), l.File, title)
+	default:
+		fmt.Println(ui.Error(i18n.T("distro.unsupported", d.PackageManager)))
+	}
+}
+
+// runDistroMenu muestra en modo plano el submenú generado a partir del descriptor
+func runDistroMenu(d distro.Descriptor) {
+	entries := availableEntries(d)
+
+	for {
+		fmt.Println(ui.Title(fmt.Sprintf("ORGMOS - %s", d.Title)))
+
+		var options []huh.Option[string]
+		for i, entry := range entries {
+			options = append(options, huh.NewOption(entry.Title, strconv.Itoa(i)))
+		}
+		options = append(options, huh.NewOption(i18n.T("common.back"), "back"))
+
+		var choice string
+		form := ui.NewForm(
+			huh.NewGroup(
+				huh.NewSelect[string]().
+					Title(i18n.T("common.select_option")).
+					Options(options...).
+					Value(&choice),
+			),
+		)
+
+		if err := form.Run(); err != nil {
+			if errors.Is(err, huh.ErrUserAborted) {
+				return
+			}
+			fmt.Println(ui.Error(i18n.T("common.menu_error")))
+			return
+		}
+
+		if choice == "back" {
+			return
+		}
+
+		idx, _ := strconv.Atoi(choice)
+		runMenuEntry(d, entries[idx])
+		fmt.Println()
+	}
+}
+
+// runMenuEntry ejecuta una opción del submenú de una distro
+func runMenuEntry(d distro.Descriptor, entry distro.MenuEntry) {
+	if entry.Action != "" {
+		action, ok := menuActions[entry.Action]
+		if !ok {
+			fmt.Println(ui.Error(i18n.T("distro.unknown_action", d.Name, entry.Action)))
+			return
+		}
+		action()
+		return
+	}
+
+	list, ok := d.FindList(entry.List)
+	if !ok {
+		fmt.Println(ui.Error(i18n.T("distro.unknown_list", d.Name, entry.List)))
+		return
+	}
+	runDistroList(d, list)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/huh"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/packages"
+	"orgmos/internal/ui"
+	"orgmos/internal/utils"
+)
+
+// runDnfInstall instala una lista .lst con dnf (Fedora y derivados)
+func runDnfInstall(listDir string, configFile string, title string) {
+	fmt.Println(ui.Title(title))
+
+	// Clonar/actualizar dotfiles con spinner
+	if err := utils.CloneOrUpdateDotfilesWithSpinner(); err != nil {
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_failed", err)))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_fallback")))
+	}
+
+	// Cargar grupos de paquetes
+	var groups []packages.PackageGroup
+	var alternates []packages.Resolution
+	var installedMap map[string]bool
+	var parseErr error
+
+	// Spinner mientras verifica
+	ui.RunSpinner(i18n.T("common.checking_installed"), func() {
+		groups, alternates, parseErr = packages.LoadDistroList(listDir, "dnf", configFile)
+		if parseErr != nil {
+			return
+		}
+
+		// Obtener todos los paquetes
+		var allPkgs []string
+		for _, g := range groups {
+			allPkgs = append(allPkgs, g.Packages...)
+		}
+
+		installedMap = packages.CheckInstalledRpm(allPkgs)
+	})
+
+	if parseErr != nil {
+		fmt.Println(ui.Error(i18n.T("common.load_packages_failed", parseErr)))
+		return
+	}
+
+	if len(groups) == 0 {
+		fmt.Println(ui.Error(i18n.T("common.load_groups_failed")))
+		return
+	}
+
+	// Filtrar paquetes no instalados
+	var toInstall []string
+	for _, group := range groups {
+		for _, pkg := range group.Packages {
+			if !installedMap[pkg] {
+				toInstall = append(toInstall, pkg)
+			}
+		}
+	}
+
+	if len(toInstall) == 0 {
+		fmt.Println(ui.Success(i18n.T("common.all_installed")))
+		offerAlternates(alternates)
+		return
+	}
+
+	// Mostrar paquetes a instalar
+	fmt.Println(ui.Info(i18n.T("common.to_install", len(toInstall))))
+	for _, pkg := range toInstall {
+		fmt.Println(ui.ListItem(pkg))
+	}
+
+	// Repositorios COPR requeridos por las listas de la distro
+	coprRepos := loadCoprRepos(listDir)
+	var pendingCopr []string
+	for _, repo := range coprRepos {
+		if !packages.CheckCoprEnabled(repo) {
+			pendingCopr = append(pendingCopr, repo)
+		}
+	}
+	if len(pendingCopr) > 0 {
+		fmt.Println(ui.Info(i18n.T("dnf.copr_pending", len(pendingCopr))))
+		for _, repo := range pendingCopr {
+			fmt.Println(ui.ListItem(repo))
+		}
+	}
+
+	// Confirmación final
+	var confirm bool
+	ui.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(i18n.T("common.will_install", len(toInstall))).
+				Affirmative(i18n.T("common.install")).
+				Negative(i18n.T("common.cancel")).
+				Value(&confirm),
+		),
+	).RunConfirm(&confirm)
+
+	if !confirm {
+		fmt.Println(ui.Warning(i18n.T("common.install_cancelled")))
+		return
+	}
+
+	if !prepareInstall("dnf", toInstall) {
+		return
+	}
+
+	if err := packages.EnableCopr(pendingCopr); err != nil {
+		fmt.Println(ui.Error(i18n.T("common.error", err)))
+		return
+	}
+
+	// Instalar paquetes
+	if err := packages.InstallDnf(toInstall); err != nil {
+		fmt.Println(ui.Error(i18n.T("common.error", err)))
+		return
+	}
+
+	fmt.Println(ui.Success(i18n.T("common.install_done")))
+	offerAlternates(alternates)
+}
+
+// loadCoprRepos lee packages/<listDir>/copr.lst (un owner/project por línea).
+// El archivo es opcional.
+func loadCoprRepos(listDir string) []string {
+	groups, err := packages.ParseLST(listDir, "copr.lst")
+	if err != nil {
+		if !os.IsNotExist(err) {
+			fmt.Println(ui.Warning(i18n.T("dnf.copr_read_failed", err)))
+		}
+		return nil
+	}
+
+	var repos []string
+	for _, g := range groups {
+		repos = append(repos, g.Packages...)
+	}
+	return repos
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"orgmos/internal/distro"
+	"orgmos/internal/i18n"
+	"orgmos/internal/packages"
+	"orgmos/internal/ui"
+	"orgmos/internal/utils"
+)
+
+var exportForce bool
+
+var exportCmd = &cobra.Command{
+	Use:   "export [nombre]",
+	Short: i18n.Key("export.short"),
+	Long:  i18n.Key("export.long"),
+	Args:  cobra.MaximumNArgs(1),
+	Run:   runExport,
+}
+
+func init() {
+	exportCmd.Flags().BoolVar(&exportForce, "force", false, i18n.Key("export.flag_force"))
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) {
+	fmt.Println(ui.Title(i18n.T("export.title")))
+
+	d, ok := detectedDistro()
+	if !ok {
+		fmt.Println(ui.Error(i18n.T("common.no_distro")))
+		return
+	}
+
+	name := ""
+	if len(args) > 0 {
+		name = strings.TrimSuffix(strings.TrimPrefix(args[0], "pkg_"), ".lst")
+	}
+	if name == "" {
+		hostname, _ := os.Hostname()
+		name = "export-" + hostname
+	}
+	file := "pkg_" + name + ".lst"
+
+	exportList(d.Dir(), d.PackageManager, listFiles(d), file, d.Title)
+
+	if utils.CommandExists("flatpak") {
+		var flatpakFiles []string
+		if lists, err := packages.DiscoverLists("flatpak"); err == nil {
+			for _, l := range lists {
+				flatpakFiles = append(flatpakFiles, l.File)
+			}
+		}
+		exportList("flatpak", "flatpak", flatpakFiles, file, "Flatpak")
+	}
+}
+
+// exportList escribe en packages/<listDir>/<file> los paquetes explícitos que
+// no aparecen en ninguna de las listas existentes
+func exportList(listDir string, manager string, existing []string, file string, title string) {
+	path := filepath.Join(utils.GetDotfilesDir(), "packages", listDir, file)
+	if _, err := os.Stat(path); err == nil && !exportForce {
+		fmt.Println(ui.Warning(i18n.T("export.exists", path)))
+		return
+	}
+
+	var groups []packages.PackageGroup
+	var total int
+	var queryErr error
+
+	ui.RunSpinner(i18n.T("export.reading", manager), func() {
+		groups, queryErr = packages.ExplicitPackages(manager)
+		if queryErr != nil {
+			return
+		}
+		for _, g := range groups {
+			total += len(g.Packages)
+		}
+
+		// La lista exportada no cuenta como cubierta para poder regenerarla
+		covered := declaredPackages(listDir, manager, existing, file)
+		groups = packages.SubtractPackages(groups, covered)
+	})
+
+	if queryErr != nil {
+		fmt.Println(ui.Error(i18n.T("common.error", queryErr)))
+		return
+	}
+
+	var remaining int
+	for _, g := range groups {
+		remaining += len(g.Packages)
+	}
+
+	fmt.Println(ui.Info(i18n.T("export.summary", title, total, total-remaining)))
+	if remaining == 0 {
+		fmt.Println(ui.Success(i18n.T("export.nothing")))
+		return
+	}
+
+	hostname, _ := os.Hostname()
+	description := i18n.T("export.description", hostname, time.Now().Format("2006-01-02"))
+	if err := packages.WriteLST(path, i18n.T("export.list_title", hostname), description, groups); err != nil {
+		fmt.Println(ui.Error(i18n.T("common.write_error", path, err)))
+		return
+	}
+
+	fmt.Println(ui.Success(i18n.T("export.done", remaining, path)))
+	for _, g := range groups {
+		fmt.Println(ui.ListItem(fmt.Sprintf("%s (%d)", g.Name, len(g.Packages))))
+	}
+}
+
+// listFiles retorna los archivos .lst declarados o descubiertos de un descriptor
+func listFiles(d distro.Descriptor) []string {
+	var files []string
+	for _, l := range d.Lists {
+		files = append(files, l.File)
+	}
+	return files
+}
+
+// declaredPackages retorna la unión de los paquetes de las listas indicadas,
+// ya traducidos a nombres nativos de la distro. skip permite omitir una lista.
+func declaredPackages(listDir string, manager string, files []string, skip string) map[string]bool {
+	declared := make(map[string]bool)
+	for pkg := range packageLists(listDir, manager, files, skip) {
+		declared[pkg] = true
+	}
+	return declared
+}
+
+// packageLists retorna, para cada paquete, los archivos .lst que lo declaran
+func packageLists(listDir string, manager string, files []string, skip string) map[string][]string {
+	lists := make(map[string][]string)
+	for _, file := range files {
+		if file == skip {
+			continue
+		}
+		groups, _, err := packages.LoadDistroList(listDir, manager, file)
+		if err != nil {
+			continue
+		}
+		for _, g := range groups {
+			for _, pkg := range g.Packages {
+				lists[pkg] = append(lists[pkg], file)
+			}
+		}
+	}
+	return lists
+}
@@ -3,10 +3,9 @@ package main
 import (
 	"fmt"
 
-	"github.com/charmbracelet/huh"
-	"github.com/charmbracelet/huh/spinner"
 	"github.com/spf13/cobra"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/packages"
 	"orgmos/internal/ui"
 	"orgmos/internal/utils"
@@ -14,8 +13,8 @@ import (
 
 var flatpakCmd = &cobra.Command{
 	Use:   "flatpak",
-	Short: "Instalador de aplicaciones Flatpak",
-	Long:  `Instala todas las aplicaciones Flatpak definidas en pkg_flatpak.toml.`,
+	Short: i18n.Key("flatpak.short"),
+	Long:  i18n.Key("flatpak.long"),
 	Run:   runFlatpakInstall,
 }
 
@@ -24,12 +23,12 @@ func init() {
 }
 
 func runFlatpakInstall(cmd *cobra.Command, args []string) {
-	fmt.Println(ui.Title("Instalador de Flatpak"))
+	fmt.Println(ui.Title(i18n.T("flatpak.title")))
 
 	// Clonar/actualizar dotfiles con spinner
 	if err := utils.CloneOrUpdateDotfilesWithSpinner(); err != nil {
-		fmt.Println(ui.Warning(fmt.Sprintf("No se pudo clonar/actualizar dotfiles: %v", err)))
-		fmt.Println(ui.Warning("Se intentará continuar con el repositorio existente si está disponible"))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_failed", err)))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_fallback")))
 	}
 
 	if !ensureFlatpakInstalled() {
@@ -42,31 +41,28 @@ func runFlatpakInstall(cmd *cobra.Command, args []string) {
 	var parseErr error
 
 	// Spinner mientras verifica
-	spinner.New().
-		Title("Verificando aplicaciones instaladas...").
-		Action(func() {
-			groups, parseErr = packages.ParseLST("flatpak", "pkg_flatpak.lst")
-			if parseErr != nil {
-				return
-			}
+	ui.RunSpinner(i18n.T("flatpak.checking"), func() {
+		groups, parseErr = packages.ParseLST("flatpak", "pkg_flatpak.lst")
+		if parseErr != nil {
+			return
+		}
 
-			// Obtener todos los paquetes
-			var allPkgs []string
-			for _, g := range groups {
-				allPkgs = append(allPkgs, g.Packages...)
-			}
+		// Obtener todos los paquetes
+		var allPkgs []string
+		for _, g := range groups {
+			allPkgs = append(allPkgs, g.Packages...)
+		}
 
-			installedMap = packages.CheckInstalledFlatpak(allPkgs)
-		}).
-		Run()
+		installedMap = packages.CheckInstalledFlatpak(allPkgs)
+	})
 
 	if parseErr != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error cargando aplicaciones Flatpak: %v", parseErr)))
+		fmt.Println(ui.Error(i18n.T("flatpak.load_failed", parseErr)))
 		return
 	}
 
 	if len(groups) == 0 {
-		fmt.Println(ui.Error("No se pudieron cargar las aplicaciones Flatpak"))
+		fmt.Println(ui.Error(i18n.T("flatpak.load_empty")))
 		return
 	}
 
@@ -81,46 +77,33 @@ func runFlatpakInstall(cmd *cobra.Command, args []string) {
 	}
 
 	if len(toInstall) == 0 {
-		fmt.Println(ui.Success("Todas las aplicaciones Flatpak ya están instaladas"))
+		fmt.Println(ui.Success(i18n.T("flatpak.all_installed")))
 		return
 	}
 
-	// Crear opciones para multi-select (preseleccionadas)
-	var options []huh.Option[string]
-	finalSelection := make([]string, len(toInstall))
-	copy(finalSelection, toInstall) // Preseleccionar todos
-	for _, pkg := range toInstall {
-		options = append(options, huh.NewOption(pkg, pkg))
+	// Mostrar las aplicaciones agrupadas por sección (preseleccionadas)
+	finalSelection, err := pickPackages(i18n.T("flatpak.select"), groups, toInstall, "flatpak")
+	if err != nil {
+		fmt.Println(ui.Warning(i18n.T("common.install_cancelled")))
+		return
 	}
 
-	// Mostrar lista multi-select preseleccionada
-	form := ui.NewForm(
-		huh.NewGroup(
-			huh.NewMultiSelect[string]().
-				Title(fmt.Sprintf("Selecciona aplicaciones Flatpak a instalar (%d disponibles)", len(toInstall))).
-				Description("Todas las aplicaciones están preseleccionadas. Deselecciona las que no deseas instalar.").
-				Options(options...).
-				Value(&finalSelection),
-		),
-	)
-
-	if err := form.Run(); err != nil {
-		fmt.Println(ui.Warning("Instalación cancelada"))
+	if len(finalSelection) == 0 {
+		fmt.Println(ui.Warning(i18n.T("flatpak.none_selected")))
 		return
 	}
 
-	if len(finalSelection) == 0 {
-		fmt.Println(ui.Warning("No se seleccionaron aplicaciones para instalar"))
+	if !prepareInstall("flatpak", finalSelection) {
 		return
 	}
 
 	// Instalar
 	if err := packages.InstallFlatpak(finalSelection); err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error: %v", err)))
+		fmt.Println(ui.Error(i18n.T("common.error", err)))
 		return
 	}
 
-	fmt.Println(ui.Success("Aplicaciones Flatpak instaladas"))
+	fmt.Println(ui.Success(i18n.T("flatpak.installed")))
 }
 
 func ensureFlatpakInstalled() bool {
@@ -128,27 +111,35 @@ func ensureFlatpakInstalled() bool {
 		return true
 	}
 
-	fmt.Println(ui.Warning("Flatpak no está instalado. Es necesario para continuar."))
+	fmt.Println(ui.Warning(i18n.T("flatpak.missing")))
+
+	// Fuera de Arch no hay AUR: flatpak se instala con el gestor de la distro
+	if !distroSupported("arch") {
+		if manager := systemPackageManager(); manager != "unknown" {
+			fmt.Println(ui.Error(i18n.T("flatpak.install_manually", manager)))
+		}
+		return false
+	}
 
 	if !packages.CheckParuInstalled() {
-		fmt.Println(ui.Warning("Paru es necesario para instalar Flatpak desde AUR."))
+		fmt.Println(ui.Warning(i18n.T("flatpak.needs_paru")))
 		if !packages.OfferInstallParu() {
-			fmt.Println(ui.Error("No se instaló Paru, cancelando."))
+			fmt.Println(ui.Error(i18n.T("flatpak.no_paru")))
 			return false
 		}
 	}
 
-	fmt.Println(ui.Info("Instalando Flatpak con Paru..."))
+	fmt.Println(ui.Info(i18n.T("flatpak.installing")))
 	if err := utils.RunCommand("paru", "-S", "--noconfirm", "--needed", "flatpak"); err != nil {
-		fmt.Println(ui.Error("No se pudo instalar Flatpak"))
+		fmt.Println(ui.Error(i18n.T("flatpak.install_failed")))
 		return false
 	}
 
 	if !utils.CommandExists("flatpak") {
-		fmt.Println(ui.Error("Flatpak sigue sin estar disponible"))
+		fmt.Println(ui.Error(i18n.T("flatpak.still_missing")))
 		return false
 	}
 
-	fmt.Println(ui.Success("Flatpak instalado correctamente"))
+	fmt.Println(ui.Success(i18n.T("flatpak.done")))
 	return true
 }
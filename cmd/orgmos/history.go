@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/spf13/cobra"
+
+	"orgmos/internal/history"
+	"orgmos/internal/i18n"
+	"orgmos/internal/packages"
+	"orgmos/internal/ui"
+)
+
+var historyLimit int
+
+var historyCmd = &cobra.Command{
+	Use:         "history",
+	Short:       i18n.Key("history.short"),
+	Long:        i18n.Key("history.long"),
+	Args:        cobra.NoArgs,
+	Run:         runHistory,
+	Annotations: supportsJSON,
+}
+
+func init() {
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, i18n.Key("history.flag_limit"))
+	rootCmd.AddCommand(historyCmd)
+}
+
+// historyReport es el resultado de 'orgmos history --output json'
+type historyReport struct {
+	Schema  string          `json:"schema"`
+	Path    string          `json:"path"`
+	Entries []history.Entry `json:"entries"`
+}
+
+func runHistory(cmd *cobra.Command, args []string) {
+	entries, err := history.Load()
+	if err != nil {
+		fmt.Fprintln(ui.TextOut(), ui.Error(i18n.T("history.read_failed", err)))
+		return
+	}
+
+	// De la más reciente a la más antigua
+	recent := make([]history.Entry, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		recent = append(recent, entries[i])
+	}
+	if historyLimit > 0 && len(recent) > historyLimit {
+		recent = recent[:historyLimit]
+	}
+
+	if ui.JSONOutput() {
+		printDocument(historyReport{Schema: schemaHistory, Path: history.Path(), Entries: recent})
+		return
+	}
+
+	fmt.Println(ui.Title(i18n.T("history.title")))
+	if len(recent) == 0 {
+		fmt.Println(ui.Warning(i18n.T("history.empty")))
+		return
+	}
+
+	var rows [][]string
+	for _, entry := range recent {
+		target := entry.List
+		if entry.Group != "" {
+			target += " / " + entry.Group
+		}
+		result := "ok"
+		if !entry.Success {
+			result = "error"
+		}
+		rows = append(rows, []string{
+			entry.Time.Format("2006-01-02 15:04"),
+			entry.Action,
+			entry.Manager,
+			target,
+			packages.SummarizePackages(entry.Packages),
+			result,
+		})
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(ui.SkyBlue).Bold(true).Padding(0, 1)
+	cellStyle := lipgloss.NewStyle().Padding(0, 1)
+	failedStyle := cellStyle.Foreground(ui.Red)
+
+	t := ui.NewTable().
+		Headers(i18n.T("common.date"), i18n.T("history.action"), i18n.T("common.manager"), i18n.T("common.list"), i18n.T("common.packages"), i18n.T("history.result")).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			switch {
+			case row == table.HeaderRow:
+				return headerStyle
+			case col == 5 && rows[row][5] != "ok":
+				return failedStyle
+			default:
+				return cellStyle
+			}
+		})
+
+	fmt.Println(t.Render())
+}
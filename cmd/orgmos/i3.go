@@ -6,47 +6,51 @@ import (
 	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/packages"
 	"orgmos/internal/ui"
 	"orgmos/internal/utils"
 )
 
 var i3Cmd = &cobra.Command{
-	Use:   "i3",
-	Short: "Instalar i3 y sus componentes",
-	Long:  `Instala i3-wm junto con todas las herramientas necesarias: dunst, polybar, rofi, picom, etc.`,
-	Run:   runI3Install,
+	Use:         "i3",
+	Short:       i18n.Key("i3.short"),
+	Long:        i18n.Key("i3.long"),
+	Run:         runI3Install,
+	Annotations: requiresArch,
 }
 
 var (
 	i3WallpaperCmd = &cobra.Command{
 		Use:   "wallpaper [random|restore|ruta]",
-		Short: "Cambiar wallpaper (solo i3)",
+		Short: i18n.Key("i3.wallpaper_short"),
 		Run:   runChangeWallpaper,
 	}
 	i3LockCmd = &cobra.Command{
 		Use:   "lock",
-		Short: "Bloquear pantalla (i3lock)",
+		Short: i18n.Key("i3.lock_short"),
 		Run:   runLock,
 	}
 	i3HotkeyCmd = &cobra.Command{
-		Use:   "hotkey",
-		Short: "Mostrar atajos configurados en i3",
-		Run:   runHotkey,
+		Use:         "hotkey",
+		Short:       i18n.Key("i3.hotkey_short"),
+		Run:         runHotkey,
+		Annotations: supportsJSON,
 	}
 	i3PowermenuCmd = &cobra.Command{
 		Use:   "powermenu",
-		Short: "Mostrar menú de energía (rofi)",
+		Short: i18n.Key("i3.powermenu_short"),
 		Run:   runPowerMenu,
 	}
 	i3MemoryCmd = &cobra.Command{
-		Use:   "memory",
-		Short: "Imprimir uso de memoria",
-		Run:   runMemory,
+		Use:         "memory",
+		Short:       i18n.Key("i3.memory_short"),
+		Run:         runMemory,
+		Annotations: supportsJSON,
 	}
 	i3ReloadCmd = &cobra.Command{
 		Use:   "reload",
-		Short: "Recargar i3 y polybar",
+		Short: i18n.Key("i3.reload_short"),
 		Run:   runReload,
 	}
 )
@@ -62,18 +66,18 @@ func init() {
 }
 
 func runI3Install(cmd *cobra.Command, args []string) {
-	fmt.Println(ui.Title("Instalación de i3 Window Manager"))
+	fmt.Println(ui.Title(i18n.T("i3.title")))
 
 	// Clonar/actualizar dotfiles con spinner
 	if err := utils.CloneOrUpdateDotfilesWithSpinner(); err != nil {
-		fmt.Println(ui.Warning(fmt.Sprintf("No se pudo clonar/actualizar dotfiles: %v", err)))
-		fmt.Println(ui.Warning("Se intentará continuar con el repositorio existente si está disponible"))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_failed", err)))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_fallback")))
 	}
 
 	// Verificar paru antes de continuar
 	if !packages.CheckParuInstalled() {
 		if !packages.OfferInstallParu() {
-			fmt.Println(ui.Warning("Instalación cancelada. Paru es necesario para instalar paquetes AUR."))
+			fmt.Println(ui.Warning(i18n.T("common.paru_required")))
 			return
 		}
 	}
@@ -81,7 +85,7 @@ func runI3Install(cmd *cobra.Command, args []string) {
 	// Cargar paquetes desde LST
 	groups, err := packages.ParseLST("arch", "pkg_i3.lst")
 	if err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error cargando paquetes: %v", err)))
+		fmt.Println(ui.Error(i18n.T("common.load_packages_failed", err)))
 		return
 	}
 
@@ -92,7 +96,7 @@ func runI3Install(cmd *cobra.Command, args []string) {
 	}
 
 	// Verificar paquetes instalados
-	fmt.Println(ui.Info("Verificando paquetes instalados..."))
+	fmt.Println(ui.Info(i18n.T("common.checking_installed")))
 	installed := packages.CheckInstalledPacman(allPackages)
 
 	var toInstall []string
@@ -103,14 +107,14 @@ func runI3Install(cmd *cobra.Command, args []string) {
 	}
 
 	if len(toInstall) == 0 {
-		fmt.Println(ui.Success("Todos los paquetes de i3 ya están instalados"))
+		fmt.Println(ui.Success(i18n.T("i3.all_installed")))
 		return
 	}
 
 	// Mostrar paquetes a instalar
-	fmt.Println(ui.Info(fmt.Sprintf("Paquetes a instalar (%d):", len(toInstall))))
+	fmt.Println(ui.Info(i18n.T("common.to_install", len(toInstall))))
 	for _, pkg := range toInstall {
-		fmt.Println(ui.Dim(fmt.Sprintf("  • %s", pkg)))
+		fmt.Println(ui.ListItem(pkg))
 	}
 
 	// Confirmación
@@ -118,24 +122,28 @@ func runI3Install(cmd *cobra.Command, args []string) {
 	form := ui.NewForm(
 		huh.NewGroup(
 			huh.NewConfirm().
-				Title(fmt.Sprintf("Se instalarán %d paquetes", len(toInstall))).
-				Affirmative("Instalar").
-				Negative("Cancelar").
+				Title(i18n.T("common.will_install", len(toInstall))).
+				Affirmative(i18n.T("common.install")).
+				Negative(i18n.T("common.cancel")).
 				Value(&confirm),
 		),
 	)
 
-	if err := form.Run(); err != nil || !confirm {
-		fmt.Println(ui.Warning("Instalación cancelada"))
+	if err := form.RunConfirm(&confirm); err != nil || !confirm {
+		fmt.Println(ui.Warning(i18n.T("common.install_cancelled")))
+		return
+	}
+
+	if !prepareInstall("pacman", toInstall) {
 		return
 	}
 
 	// Categorizar e instalar
 	categories := packages.CategorizePackages(toInstall)
 	if err := packages.InstallCategorized(categories); err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error: %v", err)))
+		fmt.Println(ui.Error(i18n.T("common.error", err)))
 		return
 	}
 
-	fmt.Println(ui.Success("i3 y componentes instalados correctamente"))
+	fmt.Println(ui.Success(i18n.T("i3.installed")))
 }
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/ui"
 	"orgmos/internal/utils"
 )
@@ -18,13 +21,13 @@ import (
 // ============ WALLPAPER ============
 
 func runChangeWallpaper(cmd *cobra.Command, args []string) {
-	homeDir, _ := os.UserHomeDir()
+	homeDir, _ := utils.GetHomeDir()
 	lastWallpaperFile := filepath.Join(homeDir, ".lastwallpaper")
 	picturesDir := filepath.Join(homeDir, "Pictures", "Wallpapers")
 
 	wallpaperDir := picturesDir
 	if _, err := os.Stat(wallpaperDir); os.IsNotExist(err) {
-		fmt.Println(ui.Error("No se encontró ~/Pictures/Wallpapers. Ejecuta 'orgmos assets' para descargarlos."))
+		fmt.Println(ui.Error(i18n.T("i3.no_wallpapers_dir")))
 		return
 	}
 
@@ -35,15 +38,15 @@ func runChangeWallpaper(cmd *cobra.Command, args []string) {
 
 	setWallpaper := func(path string) {
 		if _, err := os.Stat(path); err != nil {
-			fmt.Println(ui.Error("Wallpaper no encontrado: " + path))
+			fmt.Println(ui.Error(i18n.T("i3.wallpaper_not_found", path)))
 			return
 		}
 		if err := applyI3Wallpaper(path); err != nil {
-			fmt.Println(ui.Error(fmt.Sprintf("No se pudo aplicar el wallpaper: %v", err)))
+			fmt.Println(ui.Error(i18n.T("i3.wallpaper_failed", err)))
 			return
 		}
-		os.WriteFile(lastWallpaperFile, []byte(path), 0o644)
-		fmt.Println(ui.Success("Wallpaper cambiado: " + filepath.Base(path)))
+		utils.WriteFileForUser(lastWallpaperFile, []byte(path), 0o644)
+		fmt.Println(ui.Success(i18n.T("i3.wallpaper_changed", filepath.Base(path))))
 	}
 
 	switch action {
@@ -54,7 +57,7 @@ func runChangeWallpaper(cmd *cobra.Command, args []string) {
 			return
 		}
 		if len(wallpapers) == 0 {
-			fmt.Println(ui.Error("No hay wallpapers disponibles"))
+			fmt.Println(ui.Error(i18n.T("i3.no_wallpapers")))
 			return
 		}
 		idx := time.Now().UnixNano() % int64(len(wallpapers))
@@ -63,10 +66,10 @@ func runChangeWallpaper(cmd *cobra.Command, args []string) {
 	case "restore":
 		data, err := os.ReadFile(lastWallpaperFile)
 		if err != nil {
-			fmt.Println(ui.Warning("No hay wallpaper anterior guardado, seleccionando uno aleatorio..."))
+			fmt.Println(ui.Warning(i18n.T("i3.no_previous")))
 			wallpapers, err := listWallpapers(wallpaperDir)
 			if err != nil || len(wallpapers) == 0 {
-				fmt.Println(ui.Error("No hay wallpapers disponibles"))
+				fmt.Println(ui.Error(i18n.T("i3.no_wallpapers")))
 				return
 			}
 			idx := time.Now().UnixNano() % int64(len(wallpapers))
@@ -102,29 +105,29 @@ func listWallpapers(dir string) ([]string, error) {
 func applyI3Wallpaper(path string) error {
 	// Usar pywal para generar colores
 	if !utils.CheckDependency("wal") {
-		return fmt.Errorf("pywal no está instalado. Instala con: sudo pacman -S python-pywal")
+		return errors.New(i18n.T("i3.no_pywal"))
 	}
 
 	// Generar colores con pywal (sin aplicar wallpaper, solo colores)
 	if err := exec.Command("wal", "-i", path, "-n").Run(); err != nil {
-		return fmt.Errorf("error ejecutando wal: %w", err)
+		return fmt.Errorf(i18n.T("i3.wal_failed"), err)
 	}
 
 	// Aplicar wallpaper con xwallpaper o feh
 	if utils.CheckDependency("xwallpaper") {
 		if err := exec.Command("xwallpaper", "--zoom", path).Run(); err != nil {
-			return fmt.Errorf("error aplicando wallpaper con xwallpaper: %w", err)
+			return fmt.Errorf(i18n.T("i3.xwallpaper_failed"), err)
 		}
 	} else if utils.CheckDependency("feh") {
 		if err := exec.Command("feh", "--bg-fill", path).Run(); err != nil {
-			return fmt.Errorf("error aplicando wallpaper con feh: %w", err)
+			return fmt.Errorf(i18n.T("i3.feh_failed"), err)
 		}
 	} else {
-		return fmt.Errorf("instala xwallpaper o feh para aplicar wallpapers")
+		return errors.New(i18n.T("i3.no_setter"))
 	}
 
 	// Cargar colores en xrdb para polybar
-	homeDir, _ := os.UserHomeDir()
+	homeDir, _ := utils.GetHomeDir()
 	xresourcesPath := filepath.Join(homeDir, ".cache", "wal", "colors.Xresources")
 	if _, err := os.Stat(xresourcesPath); err == nil {
 		exec.Command("xrdb", "-merge", xresourcesPath).Run()
@@ -140,7 +143,7 @@ func applyI3Wallpaper(path string) error {
 
 func runLock(cmd *cobra.Command, args []string) {
 	if !utils.RequireDependency("i3lock") {
-		fmt.Println(ui.Error("Dependencia faltante: i3lock. Instálala con: sudo pacman -S i3lock-color"))
+		fmt.Println(ui.Error(i18n.T("i3.no_i3lock")))
 		return
 	}
 
@@ -153,36 +156,64 @@ func runHotkey(cmd *cobra.Command, args []string) {
 	showI3Hotkeys()
 }
 
-func showI3Hotkeys() {
-	homeDir, _ := os.UserHomeDir()
-	configFile := filepath.Join(homeDir, ".config", "i3", "config")
+// i3Hotkey es un atajo (bindsym) de la configuración de i3
+type i3Hotkey struct {
+	Keys   string `json:"keys"` // Con $mod reemplazado por Super, ej: "Super+Return"
+	Action string `json:"action"`
+}
 
-	data, err := os.ReadFile(configFile)
-	if err != nil {
-		fmt.Println(ui.Error("No se pudo leer la configuración de i3"))
-		return
-	}
+// hotkeysReport es el resultado de 'orgmos i3 hotkey --output json'
+type hotkeysReport struct {
+	Schema  string     `json:"schema"`
+	Config  string     `json:"config"`
+	Hotkeys []i3Hotkey `json:"hotkeys"`
+}
 
-	var hotkeys []string
-	lines := strings.Split(string(data), "\n")
-	for _, line := range lines {
+// parseI3Hotkeys extrae los bindsym de una configuración de i3
+func parseI3Hotkeys(config string) []i3Hotkey {
+	hotkeys := []i3Hotkey{}
+	for _, line := range strings.Split(config, "\n") {
 		line = strings.TrimSpace(line)
 		if strings.HasPrefix(line, "bindsym") && !strings.HasPrefix(line, "#") {
 			parts := strings.SplitN(line, " ", 3)
 			if len(parts) >= 3 {
-				key := strings.ReplaceAll(parts[1], "$mod", "Super")
-				key = strings.ReplaceAll(key, "+", " + ")
-				action := parts[2]
-				hotkeys = append(hotkeys, fmt.Sprintf("%s → %s", key, action))
+				hotkeys = append(hotkeys, i3Hotkey{
+					Keys:   strings.ReplaceAll(parts[1], "$mod", "Super"),
+					Action: parts[2],
+				})
 			}
 		}
 	}
+	return hotkeys
+}
+
+func showI3Hotkeys() {
+	homeDir, _ := utils.GetHomeDir()
+	configFile := filepath.Join(homeDir, ".config", "i3", "config")
 
-	if len(hotkeys) == 0 {
-		fmt.Println(ui.Warning("No se encontraron atajos de teclado"))
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		fmt.Fprintln(ui.TextOut(), ui.Error(i18n.T("i3.config_read_failed")))
 		return
 	}
 
+	parsed := parseI3Hotkeys(string(data))
+
+	if ui.JSONOutput() {
+		printDocument(hotkeysReport{Schema: schemaHotkeys, Config: configFile, Hotkeys: parsed})
+		return
+	}
+
+	if len(parsed) == 0 {
+		fmt.Println(ui.Warning(i18n.T("i3.no_hotkeys")))
+		return
+	}
+
+	var hotkeys []string
+	for _, hotkey := range parsed {
+		hotkeys = append(hotkeys, fmt.Sprintf("%s → %s", strings.ReplaceAll(hotkey.Keys, "+", " + "), hotkey.Action))
+	}
+
 	// Mostrar con rofi
 	rofiInput := strings.Join(hotkeys, "\n")
 	rofiCmd := exec.Command("rofi", "-dmenu", "-i", "-p", "Atajos de Teclado", "-theme-str", "window {width: 50%;} listview {lines: 15;}")
@@ -221,6 +252,10 @@ func runPowerMenu(cmd *cobra.Command, args []string) {
 func runMemory(cmd *cobra.Command, args []string) {
 	data, err := os.ReadFile("/proc/meminfo")
 	if err != nil {
+		if ui.JSONOutput() {
+			fmt.Fprintln(ui.TextOut(), ui.Error(i18n.T("i3.meminfo_failed", err)))
+			return
+		}
 		fmt.Println("?")
 		return
 	}
@@ -244,36 +279,60 @@ func runMemory(cmd *cobra.Command, args []string) {
 	}
 
 	used := total - available
+
+	if ui.JSONOutput() {
+		printDocument(memoryReport{
+			Schema:       schemaMemory,
+			TotalGiB:     roundGiB(total),
+			UsedGiB:      roundGiB(used),
+			AvailableGiB: roundGiB(available),
+		})
+		return
+	}
+
 	fmt.Printf(" %.1fG\n", used)
 }
 
+// memoryReport es el resultado de 'orgmos i3 memory --output json'
+type memoryReport struct {
+	Schema       string  `json:"schema"`
+	TotalGiB     float64 `json:"total_gib"`
+	UsedGiB      float64 `json:"used_gib"`
+	AvailableGiB float64 `json:"available_gib"`
+}
+
+// roundGiB redondea a dos decimales
+func roundGiB(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
 // ============ RELOAD ============
 
 func runReload(cmd *cobra.Command, args []string) {
-	fmt.Println(ui.Info("Recargando i3 y polybar..."))
+	fmt.Println(ui.Info(i18n.T("i3.reloading")))
 
 	// Recargar i3
 	if err := exec.Command("i3-msg", "reload").Run(); err != nil {
-		fmt.Println(ui.Warning("No se pudo recargar i3 (puede que no esté corriendo)"))
+		fmt.Println(ui.Warning(i18n.T("i3.reload_failed")))
 	} else {
-		fmt.Println(ui.Success("i3 recargado"))
+		fmt.Println(ui.Success(i18n.T("i3.reloaded")))
 	}
 
 	// Matar polybar si existe
 	exec.Command("killall", "-q", "polybar").Run()
 
 	// Esperar 0.5 segundos para asegurar que se cierre correctamente
-	fmt.Println(ui.Info("Esperando 0.5 segundos..."))
+	fmt.Println(ui.Info(i18n.T("i3.waiting")))
 	time.Sleep(500 * time.Millisecond)
 
 	// Lanzar polybar
-	homeDir, _ := os.UserHomeDir()
+	homeDir, _ := utils.GetHomeDir()
 	polybarConfig := filepath.Join(homeDir, ".config", "polybar", "config.ini")
 	if err := exec.Command("polybar", "--config="+polybarConfig, "modern").Start(); err != nil {
-		fmt.Println(ui.Warning("No se pudo lanzar polybar"))
+		fmt.Println(ui.Warning(i18n.T("i3.polybar_failed")))
 	} else {
-		fmt.Println(ui.Success("polybar lanzado"))
+		fmt.Println(ui.Success(i18n.T("i3.polybar_launched")))
 	}
 
-	fmt.Println(ui.Success("Recarga completada"))
+	fmt.Println(ui.Success(i18n.T("i3.reload_done")))
 }
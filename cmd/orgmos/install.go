@@ -4,16 +4,20 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/ui"
+	"orgmos/internal/utils"
 )
 
 var installCmd = &cobra.Command{
 	Use:   "install",
-	Short: "Instalar ORGMOS en el sistema",
-	Long:  `Crea el archivo .desktop para acceder a ORGMOS desde el menú de aplicaciones.`,
+	Short: i18n.Key("install.short"),
+	Long: i18n.Key("install.long"),
+	Args:  installArgs,
 	Run:   runInstall,
 }
 
@@ -21,19 +25,38 @@ func init() {
 	rootCmd.AddCommand(installCmd)
 }
 
+// installArgs rechaza un nombre que no es una de las listas: sin esto, un error
+// de tipeo como 'orgmos install bsae' reinstalaría el archivo .desktop
+func installArgs(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+
+	var lists []string
+	for _, c := range cmd.Commands() {
+		if c.IsAvailableCommand() {
+			lists = append(lists, c.Name())
+		}
+	}
+	if len(lists) == 0 {
+		return fmt.Errorf(i18n.T("install.no_lists"), args[0])
+	}
+	return fmt.Errorf(i18n.T("install.unknown_list"), args[0], strings.Join(lists, ", "))
+}
+
 func runInstall(cmd *cobra.Command, args []string) {
-	fmt.Println(ui.Title("Instalación de ORGMOS"))
+	fmt.Println(ui.Title(i18n.T("install.title")))
 
-	homeDir, err := os.UserHomeDir()
+	homeDir, err := utils.GetHomeDir()
 	if err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error obteniendo directorio home: %v", err)))
+		fmt.Println(ui.Error(i18n.T("common.home_error", err)))
 		return
 	}
 
 	// Crear directorio de aplicaciones si no existe
 	applicationsDir := filepath.Join(homeDir, ".local", "share", "applications")
-	if err := os.MkdirAll(applicationsDir, 0755); err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error creando directorio: %v", err)))
+	if err := utils.MkdirAllForUser(applicationsDir, 0755); err != nil {
+		fmt.Println(ui.Error(i18n.T("install.mkdir_failed", err)))
 		return
 	}
 
@@ -43,31 +66,65 @@ func runInstall(cmd *cobra.Command, args []string) {
 		execPath = "orgmos" // Fallback al nombre del comando
 	}
 
+	// Al abrirse desde el menú de aplicaciones la terminal queda para el menú y
+	// sudo pide la contraseña con un programa gráfico (SUDO_ASKPASS)
+	execArgs := []string{execPath, "menu"}
+	if askpass := utils.FindAskpass(); askpass != "" {
+		execArgs = append([]string{"env", utils.DesktopLaunchEnv + "=1", "SUDO_ASKPASS=" + askpass}, execArgs...)
+	}
+	quoted := make([]string, len(execArgs))
+	for i, arg := range execArgs {
+		quoted[i] = desktopExecArg(arg)
+	}
+	execLine := strings.Join(quoted, " ")
+
 	// Contenido del archivo .desktop
 	desktopContent := fmt.Sprintf(`[Desktop Entry]
 Name=ORGMOS
 Comment=Sistema de configuración ORGMOS - Menú interactivo
 GenericName=System Configuration
-Exec=%s menu
+Exec=%s
 Terminal=true
 Type=Application
 Icon=utilities-terminal
 Categories=System;Utility;Settings;
 Keywords=config;setup;system;orgmos;arch;debian;ubuntu;
 StartupNotify=false
-`, execPath)
+`, execLine)
 
 	// Escribir archivo .desktop
 	desktopPath := filepath.Join(applicationsDir, "orgmos.desktop")
-	if err := os.WriteFile(desktopPath, []byte(desktopContent), 0755); err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error escribiendo archivo desktop: %v", err)))
+	if err := utils.WriteFileForUser(desktopPath, []byte(desktopContent), 0755); err != nil {
+		fmt.Println(ui.Error(i18n.T("install.write_failed", err)))
 		return
 	}
 
-	fmt.Println(ui.Success("Archivo .desktop creado exitosamente"))
-	fmt.Println(ui.Info(fmt.Sprintf("Ubicación: %s", desktopPath)))
+	fmt.Println(ui.Success(i18n.T("install.done")))
+	fmt.Println(ui.Info(i18n.T("install.location", desktopPath)))
 	fmt.Println()
-	fmt.Println(ui.Dim("Ahora puedes acceder a ORGMOS desde el menú de aplicaciones."))
-	fmt.Println(ui.Dim("También puedes ejecutarlo con: orgmos menu"))
+	fmt.Println(ui.Dim(i18n.T("install.hint_menu")))
+	fmt.Println(ui.Dim(i18n.T("install.hint_cli")))
 }
 
+// desktopExecArg escribe un argumento de la línea Exec según la especificación
+// Desktop Entry: entre comillas dobles si tiene caracteres reservados (espacios,
+// comillas, $...), escapando ", `, $ y \ dentro de ellas. Además el valor del
+// archivo escapa la barra invertida y % es el inicio de un código de campo.
+func desktopExecArg(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n\"'\\><~|&;$*?#()`") {
+		return strings.ReplaceAll(arg, "%", "%%")
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range arg {
+		switch r {
+		case '"', '`', '$', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+
+	return strings.ReplaceAll(strings.ReplaceAll(b.String(), `\`, `\\`), "%", "%%")
+}
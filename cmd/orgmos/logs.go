@@ -0,0 +1,257 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/spf13/cobra"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/ui"
+	"orgmos/internal/utils"
+)
+
+// logOutputLines es la cantidad de líneas de salida que se muestran de un comando fallido
+const logOutputLines = 20
+
+var (
+	logsLast   bool
+	logsRun    string
+	logsBundle bool
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: i18n.Key("logs.short"),
+	Long:  i18n.Key("logs.long"),
+	Args:  cobra.NoArgs,
+	Run:   runLogs,
+}
+
+func init() {
+	logsCmd.Flags().BoolVar(&logsLast, "last", false, i18n.Key("logs.flag_last"))
+	logsCmd.Flags().StringVar(&logsRun, "run", "", i18n.Key("logs.flag_run"))
+	logsCmd.Flags().BoolVar(&logsBundle, "bundle", false, i18n.Key("logs.flag_bundle"))
+	logsCmd.MarkFlagsMutuallyExclusive("last", "run")
+	rootCmd.AddCommand(logsCmd)
+}
+
+func runLogs(cmd *cobra.Command, args []string) {
+	ids, err := utils.ListRunLogs()
+	if err != nil {
+		fmt.Println(ui.Error(i18n.T("logs.read_failed", err)))
+		return
+	}
+	if len(ids) == 0 {
+		fmt.Println(ui.Warning(i18n.T("logs.empty")))
+		return
+	}
+
+	id := logsRun
+	if id != "" && !utils.ValidRunID(id) {
+		fmt.Println(ui.Error(i18n.T("logs.invalid_run", id)))
+		os.Exit(1)
+	}
+	if id == "" && (logsLast || logsBundle) {
+		id = ids[0]
+	}
+
+	switch {
+	case id != "" && logsBundle:
+		bundleRunLog(id)
+	case id != "":
+		showRunLog(id)
+	default:
+		listRunLogs(ids)
+	}
+}
+
+// listRunLogs muestra una tabla con las corridas recientes
+func listRunLogs(ids []string) {
+	fmt.Println(ui.Title(i18n.T("logs.title")))
+
+	var rows [][]string
+	for _, id := range ids {
+		header, records, err := utils.ReadRunLog(id)
+		if err != nil {
+			continue
+		}
+
+		failed := 0
+		for _, record := range records {
+			if record.ExitCode != 0 {
+				failed++
+			}
+		}
+
+		rows = append(rows, []string{
+			id,
+			header.Time.Format("2006-01-02 15:04"),
+			"orgmos " + strings.Join(header.Args, " "),
+			strconv.Itoa(len(records)),
+			strconv.Itoa(failed),
+		})
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(ui.SkyBlue).Bold(true).Padding(0, 1)
+	cellStyle := lipgloss.NewStyle().Padding(0, 1)
+	failedStyle := cellStyle.Foreground(ui.Red)
+
+	t := ui.NewTable().
+		Headers("ID", i18n.T("common.date"), i18n.T("logs.command"), i18n.T("logs.processes"), i18n.T("logs.failed")).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			switch {
+			case row == table.HeaderRow:
+				return headerStyle
+			case col == 4 && rows[row][4] != "0":
+				return failedStyle
+			default:
+				return cellStyle
+			}
+		})
+
+	fmt.Println(t.Render())
+	fmt.Println(ui.Dim(i18n.T("logs.hint")))
+}
+
+// showRunLog muestra los comandos de una corrida y la salida de los que fallaron
+func showRunLog(id string) {
+	header, records, err := utils.ReadRunLog(id)
+	if err != nil {
+		fmt.Println(ui.Error(i18n.T("logs.run_read_failed", id, err)))
+		return
+	}
+
+	fmt.Println(ui.Title(i18n.T("logs.run_title", id)))
+	fmt.Println(ui.Dim(fmt.Sprintf("orgmos %s · v%s · %s", strings.Join(header.Args, " "), header.Version, header.Time.Format("2006-01-02 15:04:05"))))
+	fmt.Println()
+
+	for _, record := range records {
+		line := fmt.Sprintf("%s %s (%s)", record.Command, strings.Join(record.Args, " "), formatLogDuration(record.DurationMS))
+		if record.ExitCode == 0 {
+			fmt.Println(ui.Success(line))
+			continue
+		}
+
+		fmt.Println(ui.Failed(i18n.T("logs.exit_code", line, record.ExitCode)))
+		output := record.Stderr
+		if strings.TrimSpace(output) == "" {
+			output = record.Stdout
+		}
+		for _, outputLine := range lastLines(output, logOutputLines) {
+			fmt.Println(ui.Dim("    " + outputLine))
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(ui.Info(i18n.T("logs.full_log", utils.RunLogPath(id))))
+}
+
+// formatLogDuration formatea una duración en milisegundos
+func formatLogDuration(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).Round(100 * time.Millisecond).String()
+}
+
+// lastLines retorna las últimas n líneas no vacías de un texto
+func lastLines(text string, n int) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimRight(line, "\r"))
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// bundleRunLog empaqueta el log de una corrida junto con datos del sistema
+// en orgmos-<id>.tar.gz, en el directorio actual
+func bundleRunLog(id string) {
+	logData, err := os.ReadFile(utils.RunLogPath(id))
+	if err != nil {
+		fmt.Println(ui.Error(i18n.T("logs.run_read_failed", id, err)))
+		return
+	}
+
+	bundlePath := fmt.Sprintf("orgmos-%s.tar.gz", id)
+	if err := writeBundle(bundlePath, map[string][]byte{
+		id + ".jsonl": logData,
+		"system.txt":  []byte(systemSummary()),
+	}); err != nil {
+		fmt.Println(ui.Error(i18n.T("logs.create_failed", bundlePath, err)))
+		return
+	}
+	utils.ChownToUser(bundlePath)
+
+	absPath, _ := filepath.Abs(bundlePath)
+	fmt.Println(ui.Success(i18n.T("logs.bundled", absPath)))
+	fmt.Println(ui.Dim(i18n.T("logs.bundle_warning")))
+}
+
+// writeBundle escribe los archivos indicados en un .tar.gz
+func writeBundle(path string, files map[string][]byte) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		data := files[name]
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(data)),
+			ModTime: time.Now(),
+		}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// systemSummary describe el sistema para el reporte de error
+func systemSummary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "orgmos %s\n", Version)
+
+	if kernel, err := os.ReadFile("/proc/sys/kernel/osrelease"); err == nil {
+		fmt.Fprintf(&b, "kernel %s\n", strings.TrimSpace(string(kernel)))
+	}
+	if d, ok := detectedDistro(); ok {
+		fmt.Fprintf(&b, "distro %s (%s)\n", d.Title, d.PackageManager)
+	}
+	if osRelease, err := os.ReadFile("/etc/os-release"); err == nil {
+		b.WriteString("\n# /etc/os-release\n")
+		b.Write(osRelease)
+	}
+	return b.String()
+}
@@ -4,17 +4,21 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
 
+	"orgmos/internal/distro"
+	"orgmos/internal/i18n"
 	"orgmos/internal/ui"
 )
 
 var menuCmd = &cobra.Command{
 	Use:   "menu",
-	Short: "Menú interactivo principal",
-	Long:  `Muestra un menú interactivo con todas las opciones disponibles.`,
+	Short: i18n.Key("menu.short"),
+	Long:  i18n.Key("menu.long"),
 	Run:   runMenu,
 }
 
@@ -23,28 +27,69 @@ func init() {
 }
 
 func runMenu(cmd *cobra.Command, args []string) {
+	if !ui.Interactive() {
+		fmt.Println(ui.Error(i18n.T("menu.needs_terminal")))
+		os.Exit(1)
+	}
+
+	// En modo plano no hay pantalla completa: se pregunta línea por línea
+	if ui.Plain() {
+		runPlainMenu()
+		return
+	}
+
+	if err := runDashboard(); err != nil {
+		fmt.Println(ui.Error(i18n.T("common.menu_error")))
+		os.Exit(1)
+	}
+	fmt.Println(ui.Success(i18n.T("menu.bye")))
+}
+
+// runPlainMenu es el menú del modo plano: una pregunta por acción, con la
+// salida de cada una a continuación en la terminal
+func runPlainMenu() {
+	descriptors := menuDescriptors()
+	var entries []distro.MenuEntry
+	if len(descriptors) == 1 {
+		entries = availableEntries(descriptors[0])
+	}
+
 	for {
-		fmt.Print("\033[H\033[2J") // Clear screen
-		fmt.Println(ui.Title("ORGMOS - Sistema de Configuración"))
-		fmt.Println(ui.Dim(fmt.Sprintf("Versión %s", Version)))
+		fmt.Println(ui.Title(i18n.T("menu.title")))
+		fmt.Println(ui.Dim(i18n.T("menu.version", Version)))
 		fmt.Println()
 
+		// Con la distro detectada sus opciones van directo en el menú; si no, una
+		// opción por cada distro compatible. Luego siguen las opciones comunes.
+		var options []huh.Option[string]
+		title := i18n.T("menu.select_distro")
+		if len(descriptors) == 1 {
+			title = i18n.T("common.select_option")
+			for i, entry := range entries {
+				options = append(options, huh.NewOption(entry.Title, "entry:"+strconv.Itoa(i)))
+			}
+		} else {
+			for _, d := range descriptors {
+				options = append(options, huh.NewOption(d.Title, "distro:"+d.Name))
+			}
+		}
+		options = append(options,
+			huh.NewOption(i18n.T("common.scripts"), "scripts"),
+			huh.NewOption(i18n.T("menu.config"), "config"),
+			huh.NewOption(i18n.T("menu.assets"), "assets"),
+		)
+		if actionAvailable("flatpak") {
+			options = append(options, huh.NewOption("Flatpak", "flatpak"))
+		}
+		options = append(options, huh.NewOption(i18n.T("menu.exit"), "exit"))
+
 		var choice string
 		form := ui.NewForm(
 			huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Selecciona tu distribución").
-				Options(
-					huh.NewOption("Arch Linux", "arch"),
-					huh.NewOption("Debian", "debian"),
-					huh.NewOption("Ubuntu", "ubuntu"),
-						huh.NewOption("Scripts de instalación", "scripts"),
-						huh.NewOption("Copiar configuraciones", "config"),
-						huh.NewOption("Copiar wallpapers", "assets"),
-					huh.NewOption("Flatpak", "flatpak"),
-					huh.NewOption("Salir", "exit"),
-				).
-				Value(&choice),
+				huh.NewSelect[string]().
+					Title(title).
+					Options(options...).
+					Value(&choice),
 			),
 		)
 
@@ -52,17 +97,24 @@ func runMenu(cmd *cobra.Command, args []string) {
 			if errors.Is(err, huh.ErrUserAborted) {
 				continue
 			}
-			fmt.Println(ui.Error("Error mostrando el menú"))
+			fmt.Println(ui.Error(i18n.T("common.menu_error")))
 			return
 		}
 
+		if idx, ok := strings.CutPrefix(choice, "entry:"); ok {
+			i, _ := strconv.Atoi(idx)
+			runMenuEntry(descriptors[0], entries[i])
+			fmt.Println()
+			continue
+		}
+		if name, ok := strings.CutPrefix(choice, "distro:"); ok {
+			if d, found := findDescriptor(name); found {
+				runDistroMenu(d)
+			}
+			continue
+		}
+
 		switch choice {
-		case "arch":
-			runArchMenu()
-		case "debian":
-			runDebianMenu()
-		case "ubuntu":
-			runUbuntuMenu()
 		case "scripts":
 			runScriptsInstall(nil, nil)
 		case "config":
@@ -72,99 +124,8 @@ func runMenu(cmd *cobra.Command, args []string) {
 		case "flatpak":
 			runFlatpakInstall(nil, nil)
 		case "exit":
-			fmt.Println(ui.Success("¡Hasta luego!"))
+			fmt.Println(ui.Success(i18n.T("menu.bye")))
 			os.Exit(0)
 		}
 	}
 }
-
-// runArchMenu muestra el submenú de Arch Linux
-func runArchMenu() {
-	for {
-		fmt.Print("\033[H\033[2J") // Clear screen
-		fmt.Println(ui.Title("ORGMOS - Arch Linux"))
-		fmt.Println()
-
-		var choice string
-		form := ui.NewForm(
-			huh.NewGroup(
-				huh.NewSelect[string]().
-					Title("Selecciona una opción").
-					Options(
-						huh.NewOption("Lista base", "base"),
-						huh.NewOption("Lista extra", "extras"),
-						huh.NewOption("Lista networks", "network"),
-						huh.NewOption("Instalar Niri Window Manager", "niri"),
-						huh.NewOption("Instalar i3 Window Manager", "i3"),
-						huh.NewOption("Volver", "back"),
-					).
-					Value(&choice),
-			),
-		)
-
-		if err := form.Run(); err != nil {
-			if errors.Is(err, huh.ErrUserAborted) {
-				return
-			}
-			fmt.Println(ui.Error("Error mostrando el menú"))
-			return
-		}
-
-		switch choice {
-		case "base":
-			runPackageInstall(nil, nil)
-		case "extras":
-			runExtrasInstall(nil, nil)
-		case "network":
-			runNetworkInstall(nil, nil)
-		case "niri":
-			runNiriInstall(nil, nil)
-		case "i3":
-			runI3Install(nil, nil)
-		case "back":
-			return
-		}
-
-		// Pausa antes de volver al menú
-		fmt.Println()
-		fmt.Println(ui.Dim("Presiona Enter para continuar..."))
-		fmt.Scanln()
-	}
-}
-
-func runScriptsMenu() {
-	var script string
-	form := ui.NewForm(
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Title("Selecciona un script").
-				Options(
-					huh.NewOption("Cambiar Wallpaper", "change-wallpaper"),
-					huh.NewOption("Bloquear Pantalla", "lock"),
-					huh.NewOption("Menú de Energía", "powermenu"),
-					huh.NewOption("Mostrar Hotkeys", "hotkey"),
-					huh.NewOption("Uso de memoria", "memory"),
-					huh.NewOption("Volver", "back"),
-				).
-				Value(&script),
-		),
-	)
-
-	if err := form.Run(); err != nil || script == "back" {
-		return
-	}
-
-	// Ejecutar el comando correspondiente
-	switch script {
-	case "change-wallpaper":
-		runChangeWallpaper(nil, []string{"random"})
-	case "lock":
-		runLock(nil, nil)
-	case "powermenu":
-		runPowerMenu(nil, nil)
-	case "hotkey":
-		runHotkey(nil, nil)
-	case "memory":
-		runMemory(nil, nil)
-	}
-}
@@ -4,19 +4,20 @@ import (
 	"fmt"
 
 	"github.com/charmbracelet/huh"
-	"github.com/charmbracelet/huh/spinner"
 	"github.com/spf13/cobra"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/packages"
 	"orgmos/internal/ui"
 	"orgmos/internal/utils"
 )
 
 var networkCmd = &cobra.Command{
-	Use:   "network",
-	Short: "Instalar herramientas de red y seguridad",
-	Long:  `Instala todas las herramientas de red definidas en pkg_networks.toml.`,
-	Run:   runNetworkInstall,
+	Use:         "network",
+	Short:       i18n.Key("network.short"),
+	Long:        i18n.Key("network.long"),
+	Run:         runNetworkInstall,
+	Annotations: requiresArch,
 }
 
 func init() {
@@ -27,17 +28,17 @@ func runNetworkInstall(cmd *cobra.Command, args []string) {
 	// Verificar paru antes de continuar
 	if !packages.CheckParuInstalled() {
 		if !packages.OfferInstallParu() {
-			fmt.Println(ui.Warning("Instalación cancelada. Paru es necesario para instalar paquetes AUR."))
+			fmt.Println(ui.Warning(i18n.T("common.paru_required")))
 			return
 		}
 	}
 
-	fmt.Println(ui.Title("Herramientas de Red y Seguridad"))
+	fmt.Println(ui.Title(i18n.T("network.title")))
 
 	// Clonar/actualizar dotfiles con spinner
 	if err := utils.CloneOrUpdateDotfilesWithSpinner(); err != nil {
-		fmt.Println(ui.Warning(fmt.Sprintf("No se pudo clonar/actualizar dotfiles: %v", err)))
-		fmt.Println(ui.Warning("Se intentará continuar con el repositorio existente si está disponible"))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_failed", err)))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_fallback")))
 	}
 
 	// Cargar grupos de paquetes
@@ -46,31 +47,28 @@ func runNetworkInstall(cmd *cobra.Command, args []string) {
 	var parseErr error
 
 	// Spinner mientras verifica
-	spinner.New().
-		Title("Verificando paquetes instalados...").
-		Action(func() {
-			groups, parseErr = packages.ParseLST("arch", "pkg_networks.lst")
-			if parseErr != nil {
-				return
-			}
+	ui.RunSpinner(i18n.T("common.checking_installed"), func() {
+		groups, parseErr = packages.ParseLST("arch", "pkg_networks.lst")
+		if parseErr != nil {
+			return
+		}
 
-			// Obtener todos los paquetes
-			var allPkgs []string
-			for _, g := range groups {
-				allPkgs = append(allPkgs, g.Packages...)
-			}
+		// Obtener todos los paquetes
+		var allPkgs []string
+		for _, g := range groups {
+			allPkgs = append(allPkgs, g.Packages...)
+		}
 
-			installedMap = packages.CheckInstalledPacman(allPkgs)
-		}).
-		Run()
+		installedMap = packages.CheckInstalledPacman(allPkgs)
+	})
 
 	if parseErr != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error cargando paquetes: %v", parseErr)))
+		fmt.Println(ui.Error(i18n.T("common.load_packages_failed", parseErr)))
 		return
 	}
 
 	if len(groups) == 0 {
-		fmt.Println(ui.Error("No se pudieron cargar los grupos de paquetes"))
+		fmt.Println(ui.Error(i18n.T("common.load_groups_failed")))
 		return
 	}
 
@@ -85,14 +83,14 @@ func runNetworkInstall(cmd *cobra.Command, args []string) {
 	}
 
 	if len(toInstall) == 0 {
-		fmt.Println(ui.Success("Todas las herramientas de red ya están instaladas"))
+		fmt.Println(ui.Success(i18n.T("network.all_installed")))
 		return
 	}
 
 	// Mostrar paquetes a instalar
-	fmt.Println(ui.Info(fmt.Sprintf("Paquetes a instalar (%d):", len(toInstall))))
+	fmt.Println(ui.Info(i18n.T("common.to_install", len(toInstall))))
 	for _, pkg := range toInstall {
-		fmt.Println(ui.Dim(fmt.Sprintf("  • %s", pkg)))
+		fmt.Println(ui.ListItem(pkg))
 	}
 
 	// Confirmación final
@@ -100,28 +98,32 @@ func runNetworkInstall(cmd *cobra.Command, args []string) {
 	ui.NewForm(
 		huh.NewGroup(
 			huh.NewConfirm().
-				Title(fmt.Sprintf("Se instalarán %d paquetes", len(toInstall))).
-				Affirmative("Instalar").
-				Negative("Cancelar").
+				Title(i18n.T("common.will_install", len(toInstall))).
+				Affirmative(i18n.T("common.install")).
+				Negative(i18n.T("common.cancel")).
 				Value(&confirm),
 		),
-	).Run()
+	).RunConfirm(&confirm)
 
 	if !confirm {
-		fmt.Println(ui.Warning("Instalación cancelada"))
+		fmt.Println(ui.Warning(i18n.T("common.install_cancelled")))
+		return
+	}
+
+	if !prepareInstall("pacman", toInstall) {
 		return
 	}
 
 	// Categorizar paquetes por origen
-	fmt.Println(ui.Info("Categorizando paquetes por origen..."))
+	fmt.Println(ui.Info(i18n.T("common.categorizing")))
 	categories := packages.CategorizePackages(toInstall)
 
 	// Instalar
 	if err := packages.InstallCategorized(categories); err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error: %v", err)))
+		fmt.Println(ui.Error(i18n.T("common.error", err)))
 		return
 	}
 
-	fmt.Println(ui.Success("Herramientas de red instaladas correctamente"))
+	fmt.Println(ui.Success(i18n.T("network.installed")))
 }
 
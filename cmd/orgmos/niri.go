@@ -8,16 +8,18 @@ import (
 	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/packages"
 	"orgmos/internal/ui"
 	"orgmos/internal/utils"
 )
 
 var niriCmd = &cobra.Command{
-	Use:   "niri",
-	Short: "Instalar Niri Window Manager",
-	Long:  `Instala Niri junto con todas las herramientas necesarias para Wayland.`,
-	Run:   runNiriInstall,
+	Use:         "niri",
+	Short:       i18n.Key("niri.short"),
+	Long:        i18n.Key("niri.long"),
+	Run:         runNiriInstall,
+	Annotations: requiresArch,
 }
 
 func init() {
@@ -28,17 +30,17 @@ func runNiriInstall(cmd *cobra.Command, args []string) {
 	// Verificar paru antes de continuar
 	if !packages.CheckParuInstalled() {
 		if !packages.OfferInstallParu() {
-			fmt.Println(ui.Warning("Instalación cancelada. Paru es necesario para instalar paquetes AUR."))
+			fmt.Println(ui.Warning(i18n.T("common.paru_required")))
 			return
 		}
 	}
 
-	fmt.Println(ui.Title("Instalación de Niri Window Manager"))
+	fmt.Println(ui.Title(i18n.T("niri.title")))
 
 	// Clonar/actualizar dotfiles con spinner
 	if err := utils.CloneOrUpdateDotfilesWithSpinner(); err != nil {
-		fmt.Println(ui.Warning(fmt.Sprintf("No se pudo clonar/actualizar dotfiles: %v", err)))
-		fmt.Println(ui.Warning("Se intentará continuar con el repositorio existente si está disponible"))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_failed", err)))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_fallback")))
 	}
 
 	// Confirmación antes de ejecutar script curl
@@ -46,51 +48,29 @@ func runNiriInstall(cmd *cobra.Command, args []string) {
 	form := ui.NewForm(
 		huh.NewGroup(
 			huh.NewConfirm().
-				Title("Instalar Niri y DMS Shell").
-				Description("Se ejecutará el script de instalación de Dank Linux que instalará niri y dependencias de DMS (dsearch, dgop, etc.).").
-				Affirmative("Continuar").
-				Negative("Cancelar").
+				Title(i18n.T("niri.confirm")).
+				Description(i18n.T("niri.confirm_desc")).
+				Affirmative(i18n.T("common.continue")).
+				Negative(i18n.T("common.cancel")).
 				Value(&confirmScript),
 		),
 	)
 
-	if err := form.Run(); err != nil || !confirmScript {
-		fmt.Println(ui.Warning("Instalación cancelada"))
+	if err := form.RunConfirm(&confirmScript); err != nil || !confirmScript {
+		fmt.Println(ui.Warning(i18n.T("common.install_cancelled")))
 		return
 	}
 
 	// Ejecutar script curl para instalar niri y dependencias de DMS
-	fmt.Println(ui.Info("Ejecutando script de instalación de Dank Linux..."))
+	fmt.Println(ui.Info(i18n.T("niri.running_script")))
 
-	// Ejecutar curl y pipe a sh
-	curlCmd := exec.Command("curl", "-fsSL", "https://install.danklinux.com")
-	shCmd := exec.Command("sh")
-
-	// Conectar stdout de curl a stdin de sh
-	shCmd.Stdin, _ = curlCmd.StdoutPipe()
-	shCmd.Stdout = os.Stdout
-	shCmd.Stderr = os.Stderr
-
-	// Iniciar sh primero
-	if err := shCmd.Start(); err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error iniciando sh: %v", err)))
-		return
-	}
-
-	// Ejecutar curl
-	if err := curlCmd.Run(); err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error ejecutando curl: %v", err)))
-		shCmd.Process.Kill()
-		return
-	}
-
-	// Esperar a que sh termine
-	if err := shCmd.Wait(); err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error ejecutando script de instalación: %v", err)))
+	// Equivalente a curl | sh, cancelable con Ctrl+C
+	if err := utils.RunRemoteScript("https://install.danklinux.com"); err != nil {
+		fmt.Println(ui.Error(i18n.T("niri.script_failed", err)))
 		return
 	}
 
-	fmt.Println(ui.Success("Script de instalación completado"))
+	fmt.Println(ui.Success(i18n.T("niri.script_done")))
 
 	// Paquetes que el script curl ya instaló (para filtrarlos)
 	curlInstalledPackages := map[string]bool{
@@ -106,7 +86,7 @@ func runNiriInstall(cmd *cobra.Command, args []string) {
 	// Cargar paquetes desde LST
 	groups, err := packages.ParseLST("arch", "pkg_niri.lst")
 	if err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error cargando paquetes: %v", err)))
+		fmt.Println(ui.Error(i18n.T("common.load_packages_failed", err)))
 		return
 	}
 
@@ -121,7 +101,7 @@ func runNiriInstall(cmd *cobra.Command, args []string) {
 	}
 
 	// Verificar paquetes instalados
-	fmt.Println(ui.Info("Verificando paquetes adicionales instalados..."))
+	fmt.Println(ui.Info(i18n.T("niri.checking_extra")))
 	installed := packages.CheckInstalledPacman(allPackages)
 
 	var toInstall []string
@@ -139,9 +119,9 @@ func runNiriInstall(cmd *cobra.Command, args []string) {
 
 	if len(toInstall) > 0 {
 		// Mostrar paquetes a instalar
-		fmt.Println(ui.Info(fmt.Sprintf("Paquetes adicionales a instalar (%d):", len(toInstall))))
+		fmt.Println(ui.Info(i18n.T("niri.extra_to_install", len(toInstall))))
 		for _, pkg := range toInstall {
-			fmt.Println(ui.Dim(fmt.Sprintf("  • %s", pkg)))
+			fmt.Println(ui.ListItem(pkg))
 		}
 
 		// Confirmación
@@ -149,30 +129,30 @@ func runNiriInstall(cmd *cobra.Command, args []string) {
 		form := ui.NewForm(
 			huh.NewGroup(
 				huh.NewConfirm().
-					Title(fmt.Sprintf("Se instalarán %d paquetes adicionales", len(toInstall))).
-					Affirmative("Instalar").
-					Negative("Cancelar").
+					Title(i18n.T("niri.extra_confirm", len(toInstall))).
+					Affirmative(i18n.T("common.install")).
+					Negative(i18n.T("common.cancel")).
 					Value(&confirm),
 			),
 		)
 
-		if err := form.Run(); err != nil || !confirm {
-			fmt.Println(ui.Warning("Instalación de paquetes adicionales cancelada"))
+		if err := form.RunConfirm(&confirm); err != nil || !confirm || !prepareInstall("pacman", toInstall) {
+			fmt.Println(ui.Warning(i18n.T("niri.extra_cancelled")))
 		} else {
 			// Categorizar e instalar
 			categories := packages.CategorizePackages(toInstall)
 			if err := packages.InstallCategorized(categories); err != nil {
-				fmt.Println(ui.Error(fmt.Sprintf("Error: %v", err)))
+				fmt.Println(ui.Error(i18n.T("common.error", err)))
 				return
 			}
-			fmt.Println(ui.Success("Paquetes adicionales instalados correctamente"))
+			fmt.Println(ui.Success(i18n.T("niri.extra_installed")))
 		}
 	} else {
-		fmt.Println(ui.Success("Todos los paquetes adicionales ya están instalados"))
+		fmt.Println(ui.Success(i18n.T("niri.extra_all_installed")))
 	}
 
 	// Ejecutar comandos post-instalación de DMS greeter
-	fmt.Println(ui.Info("Configurando DMS greeter..."))
+	fmt.Println(ui.Info(i18n.T("niri.greeter")))
 
 	// dms greeter enable
 	enableCmd := exec.Command("dms", "greeter", "enable")
@@ -180,9 +160,9 @@ func runNiriInstall(cmd *cobra.Command, args []string) {
 	enableCmd.Stderr = os.Stderr
 
 	if err := enableCmd.Run(); err != nil {
-		fmt.Println(ui.Warning(fmt.Sprintf("No se pudo ejecutar 'dms greeter enable': %v", err)))
+		fmt.Println(ui.Warning(i18n.T("niri.greeter_enable_failed", err)))
 	} else {
-		fmt.Println(ui.Success("DMS greeter habilitado"))
+		fmt.Println(ui.Success(i18n.T("niri.greeter_enabled")))
 	}
 
 	// dms greeter sync
@@ -191,20 +171,20 @@ func runNiriInstall(cmd *cobra.Command, args []string) {
 	syncCmd.Stderr = os.Stderr
 
 	if err := syncCmd.Run(); err != nil {
-		fmt.Println(ui.Warning(fmt.Sprintf("No se pudo ejecutar 'dms greeter sync': %v", err)))
+		fmt.Println(ui.Warning(i18n.T("niri.greeter_sync_failed", err)))
 	} else {
-		fmt.Println(ui.Success("DMS greeter sincronizado"))
+		fmt.Println(ui.Success(i18n.T("niri.greeter_synced")))
 	}
 
 	// Habilitar servicio de usuario
 	enableNiriService()
 
-	fmt.Println(ui.Success("Niri y DMS Shell instalados correctamente"))
-	fmt.Println(ui.Info("Reinicia tu sesión o ejecuta: systemctl --user start niri.service"))
+	fmt.Println(ui.Success(i18n.T("niri.installed")))
+	fmt.Println(ui.Info(i18n.T("niri.restart_hint")))
 }
 
 func enableNiriService() {
-	fmt.Println(ui.Info("Habilitando servicio Niri..."))
+	fmt.Println(ui.Info(i18n.T("niri.enabling_service")))
 
 	// Agregar dms como dependencia del servicio
 	cmd := exec.Command("systemctl", "--user", "add-wants", "niri.service", "dms")
@@ -212,8 +192,8 @@ func enableNiriService() {
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Run(); err != nil {
-		fmt.Println(ui.Warning("No se pudo agregar dms como dependencia (puede que ya esté configurado)"))
+		fmt.Println(ui.Warning(i18n.T("niri.dms_dependency_failed")))
 	} else {
-		fmt.Println(ui.Success("Servicio Niri configurado"))
+		fmt.Println(ui.Success(i18n.T("niri.service_done")))
 	}
 }
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/packages"
+	"orgmos/internal/ui"
+	"orgmos/internal/utils"
+)
+
+var orphansCmd = &cobra.Command{
+	Use:         "orphans",
+	Short:       i18n.Key("orphans.short"),
+	Long:        i18n.Key("orphans.long"),
+	Run:         runOrphans,
+	Annotations: supportsJSON,
+}
+
+func init() {
+	rootCmd.AddCommand(orphansCmd)
+}
+
+// orphanSource es un conjunto de listas con su gestor de paquetes
+type orphanSource struct {
+	title   string
+	listDir string
+	manager string
+	files   []string
+}
+
+// orphanPackage es un paquete no declarado en el documento JSON
+type orphanPackage struct {
+	Name        string `json:"name"`
+	Size        string `json:"size,omitempty"`
+	InstallDate string `json:"install_date,omitempty"` // RFC 3339
+}
+
+// orphanGroup agrupa paquetes no declarados por repositorio o sección
+type orphanGroup struct {
+	Name     string          `json:"name"`
+	Packages []orphanPackage `json:"packages"`
+}
+
+// orphanSourceReport son los paquetes no declarados de un gestor
+type orphanSourceReport struct {
+	Title   string        `json:"title"`
+	Manager string        `json:"manager"`
+	Groups  []orphanGroup `json:"groups"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// orphansReport es el resultado de 'orgmos orphans --output json'
+type orphansReport struct {
+	Schema  string               `json:"schema"`
+	Sources []orphanSourceReport `json:"sources"`
+}
+
+func runOrphans(cmd *cobra.Command, args []string) {
+	fmt.Fprintln(ui.TextOut(), ui.Title(i18n.T("orphans.title")))
+
+	d, ok := detectedDistro()
+	if !ok {
+		fmt.Fprintln(ui.TextOut(), ui.Error(i18n.T("common.no_distro")))
+		return
+	}
+
+	sources := []orphanSource{{title: d.Title, listDir: d.Dir(), manager: d.PackageManager, files: listFiles(d)}}
+	if utils.CommandExists("flatpak") {
+		flatpakSource := orphanSource{title: "Flatpak", listDir: "flatpak", manager: "flatpak"}
+		if lists, err := packages.DiscoverLists("flatpak"); err == nil {
+			for _, l := range lists {
+				flatpakSource.files = append(flatpakSource.files, l.File)
+			}
+		}
+		sources = append(sources, flatpakSource)
+	}
+
+	// En modo JSON solo se reporta, sin acciones interactivas
+	if ui.JSONOutput() {
+		report := orphansReport{Schema: schemaOrphans, Sources: []orphanSourceReport{}}
+		for _, source := range sources {
+			report.Sources = append(report.Sources, orphanReport(source))
+		}
+		printDocument(report)
+		return
+	}
+
+	for _, source := range sources {
+		if !reviewOrphans(source) {
+			return
+		}
+	}
+}
+
+// findOrphans obtiene los paquetes instalados explícitamente que no están en
+// ninguna lista de la fuente, junto con su tamaño y fecha de instalación
+func findOrphans(source orphanSource) ([]packages.PackageGroup, map[string]packages.InstalledDetail, error) {
+	explicit, err := packages.ExplicitPackages(source.manager)
+	if err != nil {
+		return nil, nil, err
+	}
+	orphans := packages.SubtractPackages(explicit, declaredPackages(source.listDir, source.manager, source.files, ""))
+
+	var all []string
+	for _, g := range orphans {
+		all = append(all, g.Packages...)
+	}
+	return orphans, packages.InstalledDetails(source.manager, all), nil
+}
+
+// orphanReport arma el reporte JSON de una fuente
+func orphanReport(source orphanSource) orphanSourceReport {
+	report := orphanSourceReport{Title: source.title, Manager: source.manager, Groups: []orphanGroup{}}
+
+	orphans, details, err := findOrphans(source)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+
+	for _, g := range orphans {
+		group := orphanGroup{Name: g.Name}
+		for _, pkg := range g.Packages {
+			entry := orphanPackage{Name: pkg, Size: details[pkg].Size}
+			if date := details[pkg].InstallDate; !date.IsZero() {
+				entry.InstallDate = date.Format(time.RFC3339)
+			}
+			group.Packages = append(group.Packages, entry)
+		}
+		report.Groups = append(report.Groups, group)
+	}
+	return report
+}
+
+// reviewOrphans muestra los paquetes no declarados de una fuente y ofrece acciones.
+// Retorna false si el usuario canceló.
+func reviewOrphans(source orphanSource) bool {
+	var orphans []packages.PackageGroup
+	var details map[string]packages.InstalledDetail
+	var queryErr error
+
+	ui.RunSpinner(i18n.T("orphans.searching", source.title), func() {
+		orphans, details, queryErr = findOrphans(source)
+	})
+
+	if queryErr != nil {
+		fmt.Println(ui.Error(i18n.T("common.error", queryErr)))
+		return true
+	}
+
+	if len(orphans) == 0 {
+		fmt.Println(ui.Success(i18n.T("orphans.none", source.title)))
+		return true
+	}
+
+	// Mostrar el reporte agrupado por repositorio o sección
+	var items []ui.PickerItem
+	groupOf := make(map[string]string)
+	for _, g := range orphans {
+		fmt.Println(ui.Info(fmt.Sprintf("%s · %s (%d):", source.title, g.Name, len(g.Packages))))
+		for _, pkg := range g.Packages {
+			detail := orphanDetail(details[pkg])
+			fmt.Println(ui.ListItem(fmt.Sprintf("%-32s %s", pkg, detail)))
+
+			groupOf[pkg] = g.Name
+			items = append(items, ui.PickerItem{Group: g.Name, Value: pkg, Label: pkg, Detail: detail})
+		}
+	}
+	fmt.Println()
+
+	selected, err := ui.RunPicker(
+		i18n.T("orphans.picker_title", source.title),
+		i18n.T("orphans.picker_desc"),
+		items,
+	)
+	if err != nil {
+		if errors.Is(err, huh.ErrUserAborted) || errors.Is(err, ui.ErrNoTerminal) {
+			fmt.Println(ui.Warning(i18n.T("common.operation_cancelled")))
+			return false
+		}
+		fmt.Println(ui.Error(i18n.T("common.error", err)))
+		return false
+	}
+	if len(selected) == 0 {
+		fmt.Println(ui.Dim(i18n.T("orphans.none_selected")))
+		return true
+	}
+
+	var action string
+	form := ui.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(i18n.T("orphans.action", len(selected))).
+				Options(
+					huh.NewOption(i18n.T("orphans.action_add"), "append"),
+					huh.NewOption(i18n.T("orphans.action_remove"), "remove"),
+					huh.NewOption(i18n.T("orphans.action_none"), "skip"),
+				).
+				Value(&action),
+		),
+	)
+	if err := form.Run(); err != nil {
+		fmt.Println(ui.Warning(i18n.T("common.operation_cancelled")))
+		return false
+	}
+
+	switch action {
+	case "append":
+		appendOrphans(source, selected, groupOf)
+	case "remove":
+		if err := packages.RemovePackages(source.manager, selected); err != nil {
+			fmt.Println(ui.Error(i18n.T("common.error", err)))
+			return true
+		}
+		fmt.Println(ui.Success(i18n.T("common.removed_n", len(selected))))
+	}
+
+	return true
+}
+
+// orphanDetail formatea tamaño y fecha de instalación
+func orphanDetail(detail packages.InstalledDetail) string {
+	var parts []string
+	if detail.Size != "" {
+		parts = append(parts, detail.Size)
+	}
+	if !detail.InstallDate.IsZero() {
+		parts = append(parts, i18n.T("orphans.installed_on", detail.InstallDate.Format("2006-01-02")))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// appendOrphans agrega los paquetes a la lista y sección que elija el usuario.
+// Con "según origen" cada paquete va a una sección con el nombre de su repositorio.
+func appendOrphans(source orphanSource, selected []string, groupOf map[string]string) {
+	if len(source.files) == 0 {
+		fmt.Println(ui.Warning(i18n.T("orphans.no_lists")))
+		return
+	}
+
+	var file string
+	var fileOptions []huh.Option[string]
+	for _, f := range source.files {
+		fileOptions = append(fileOptions, huh.NewOption(f, f))
+	}
+	if err := ui.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(i18n.T("orphans.select_list")).
+				Options(fileOptions...).
+				Value(&file),
+		),
+	).Run(); err != nil {
+		fmt.Println(ui.Warning(i18n.T("common.operation_cancelled")))
+		return
+	}
+
+	path := filepath.Join(utils.GetDotfilesDir(), "packages", source.listDir, file)
+	sections, err := packages.ListSections(path)
+	if err != nil {
+		fmt.Println(ui.Error(i18n.T("common.read_failed", path, err)))
+		return
+	}
+
+	const byOrigin = "\x00origen"
+	section := byOrigin
+	sectionOptions := []huh.Option[string]{huh.NewOption(i18n.T("orphans.by_origin"), byOrigin)}
+	for _, s := range sections {
+		sectionOptions = append(sectionOptions, huh.NewOption(s, s))
+	}
+	if err := ui.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(i18n.T("orphans.select_section")).
+				Options(sectionOptions...).
+				Value(&section),
+		),
+	).Run(); err != nil {
+		fmt.Println(ui.Warning(i18n.T("common.operation_cancelled")))
+		return
+	}
+
+	// Agrupar por sección de destino manteniendo el orden
+	var order []string
+	bySection := make(map[string][]string)
+	for _, pkg := range selected {
+		target := section
+		if section == byOrigin {
+			target = groupOf[pkg]
+		}
+		if _, ok := bySection[target]; !ok {
+			order = append(order, target)
+		}
+		bySection[target] = append(bySection[target], pkg)
+	}
+
+	for _, target := range order {
+		if err := packages.AppendToLST(path, target, bySection[target]); err != nil {
+			fmt.Println(ui.Error(i18n.T("common.write_error", path, err)))
+			return
+		}
+	}
+
+	fmt.Println(ui.Success(i18n.T("orphans.added", len(selected), file)))
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/ui"
+)
+
+// outputFormat es el valor de --output: "text" o "json"
+var outputFormat string
+
+// Identificadores de los documentos de --output json, descritos en docs/json-output.md.
+// La versión solo cambia si un campo existente cambia de tipo o desaparece.
+const (
+	schemaStatus  = "orgmos.status/v1"
+	schemaOrphans = "orgmos.orphans/v1"
+	schemaHistory = "orgmos.history/v1"
+	schemaHotkeys = "orgmos.hotkeys/v1"
+	schemaMemory  = "orgmos.memory/v1"
+	schemaVersion = "orgmos.version/v1"
+)
+
+// jsonAnnotation marca los comandos que admiten --output json
+const jsonAnnotation = "orgmos/json"
+
+// supportsJSON es la anotación que se agrega a esos comandos
+var supportsJSON = map[string]string{jsonAnnotation: "true"}
+
+// requireJSONSupport rechaza --output json en comandos que no lo admiten
+// (por ejemplo los interactivos, que esperarían respuestas en la terminal)
+func requireJSONSupport(cmd *cobra.Command, args []string) error {
+	if !ui.JSONOutput() || cmd.Annotations[jsonAnnotation] != "" {
+		return nil
+	}
+
+	err := fmt.Errorf(i18n.T("output.unsupported"), cmd.CommandPath())
+	fmt.Fprintln(ui.TextOut(), ui.Error(err.Error()))
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return err
+}
+
+// printDocument escribe el documento JSON de un comando en stdout; si falla, termina con error
+func printDocument(doc any) {
+	if err := ui.PrintJSON(os.Stdout, doc); err != nil {
+		fmt.Fprintln(os.Stderr, ui.Error(i18n.T("output.failed", err)))
+		os.Exit(1)
+	}
+}
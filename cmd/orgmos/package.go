@@ -4,20 +4,21 @@ import (
 	"fmt"
 
 	"github.com/charmbracelet/huh"
-	"github.com/charmbracelet/huh/spinner"
 	"github.com/spf13/cobra"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/packages"
 	"orgmos/internal/ui"
 	"orgmos/internal/utils"
 )
 
 var packageCmd = &cobra.Command{
-	Use:     "general",
-	Aliases: []string{"package", "base"},
-	Short:   "Instalar paquetes base",
-	Long:    `Instala todos los paquetes definidos en pkg_base.lst.`,
-	Run:     runPackageInstall,
+	Use:         "general",
+	Aliases:     []string{"package", "base"},
+	Short:       i18n.Key("package.short"),
+	Long:        i18n.Key("package.long"),
+	Run:         runPackageInstall,
+	Annotations: requiresArch,
 }
 
 func init() {
@@ -25,32 +26,37 @@ func init() {
 }
 
 func runPackageInstall(cmd *cobra.Command, args []string) {
-	fmt.Println(ui.Title("Instalador de Paquetes Base"))
+	runPacmanInstall("arch", "pkg_base.lst", i18n.T("package.title"))
+}
+
+// runPacmanInstall instala una lista .lst con pacman, paru o yay según elija el usuario
+func runPacmanInstall(listDir string, configFile string, title string) {
+	fmt.Println(ui.Title(title))
 
 	// Clonar/actualizar dotfiles con spinner
 	if err := utils.CloneOrUpdateDotfilesWithSpinner(); err != nil {
-		fmt.Println(ui.Warning(fmt.Sprintf("No se pudo clonar/actualizar dotfiles: %v", err)))
-		fmt.Println(ui.Warning("Se intentará continuar con el repositorio existente si está disponible"))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_failed", err)))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_fallback")))
 	}
 
-	// Seleccionar instalador
-	var installer string
+	// Seleccionar instalador (sin terminal y con --yes se usa pacman)
+	installer := "pacman"
 	form := ui.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[string]().
-				Title("Selecciona el instalador").
-				Description("Elige el gestor de paquetes que deseas usar").
+				Title(i18n.T("common.select_installer")).
+				Description(i18n.T("common.select_installer_desc")).
 				Options(
-					huh.NewOption("pacman (solo repos oficiales)", "pacman"),
-					huh.NewOption("paru (AUR + repos oficiales)", "paru"),
-					huh.NewOption("yay (AUR + repos oficiales)", "yay"),
+					huh.NewOption(i18n.T("common.installer_pacman"), "pacman"),
+					huh.NewOption(i18n.T("common.installer_paru"), "paru"),
+					huh.NewOption(i18n.T("common.installer_yay"), "yay"),
 				).
 				Value(&installer),
 		),
 	)
 
-	if err := form.Run(); err != nil {
-		fmt.Println(ui.Warning("Instalación cancelada"))
+	if err := form.RunOrDefault(); err != nil {
+		fmt.Println(ui.Warning(i18n.T("common.install_cancelled")))
 		return
 	}
 
@@ -58,68 +64,66 @@ func runPackageInstall(cmd *cobra.Command, args []string) {
 	if !packages.CheckInstallerAvailable(installer) {
 		if installer == "paru" {
 			// Intentar compilar paru
-			fmt.Println(ui.Info("Paru no está instalado. Intentando compilar..."))
+			fmt.Println(ui.Info(i18n.T("common.paru_building")))
 			if !packages.OfferInstallParu() {
 				// Si falla, ofrecer yay como fallback
-				fmt.Println(ui.Warning("No se pudo compilar paru. ¿Deseas usar yay como alternativa?"))
+				fmt.Println(ui.Warning(i18n.T("common.paru_build_failed")))
 				var useYay bool
 				form2 := ui.NewForm(
 					huh.NewGroup(
 						huh.NewConfirm().
-							Title("Usar yay como alternativa").
-							Description("yay puede instalar paquetes AUR y repos oficiales").
-							Affirmative("Sí, usar yay").
-							Negative("No, cancelar").
+							Title(i18n.T("common.use_yay")).
+							Description(i18n.T("common.use_yay_desc")).
+							Affirmative(i18n.T("common.use_yay_yes")).
+							Negative(i18n.T("common.no_cancel")).
 							Value(&useYay),
 					),
 				)
-				if err := form2.Run(); err != nil || !useYay {
-					fmt.Println(ui.Warning("Instalación cancelada"))
+				if err := form2.RunConfirm(&useYay); err != nil || !useYay {
+					fmt.Println(ui.Warning(i18n.T("common.install_cancelled")))
 					return
 				}
 				if !packages.CheckYayInstalled() {
-					fmt.Println(ui.Error("yay no está instalado. Instálalo primero con: yay -S yay"))
+					fmt.Println(ui.Error(i18n.T("common.yay_missing")))
 					return
 				}
 				installer = "yay"
 			}
 		} else {
-			fmt.Println(ui.Error(fmt.Sprintf("%s no está instalado. Instálalo primero.", installer)))
+			fmt.Println(ui.Error(i18n.T("common.installer_missing", installer)))
 			return
 		}
 	}
 
 	// Cargar grupos de paquetes
 	var groups []packages.PackageGroup
+	var alternates []packages.Resolution
 	var installedMap map[string]bool
 	var parseErr error
 
 	// Spinner mientras verifica
-	spinner.New().
-		Title("Verificando paquetes instalados...").
-		Action(func() {
-			groups, parseErr = packages.ParseLST("arch", "pkg_base.lst")
-			if parseErr != nil {
-				return
-			}
+	ui.RunSpinner(i18n.T("common.checking_installed"), func() {
+		groups, alternates, parseErr = packages.LoadDistroList(listDir, "pacman", configFile)
+		if parseErr != nil {
+			return
+		}
 
-			// Obtener todos los paquetes
-			var allPkgs []string
-			for _, g := range groups {
-				allPkgs = append(allPkgs, g.Packages...)
-			}
+		// Obtener todos los paquetes
+		var allPkgs []string
+		for _, g := range groups {
+			allPkgs = append(allPkgs, g.Packages...)
+		}
 
-			installedMap = packages.CheckInstalledPacman(allPkgs)
-		}).
-		Run()
+		installedMap = packages.CheckInstalledPacman(allPkgs)
+	})
 
 	if parseErr != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error cargando paquetes: %v", parseErr)))
+		fmt.Println(ui.Error(i18n.T("common.load_packages_failed", parseErr)))
 		return
 	}
 
 	if len(groups) == 0 {
-		fmt.Println(ui.Error("No se pudieron cargar los grupos de paquetes"))
+		fmt.Println(ui.Error(i18n.T("common.load_groups_failed")))
 		return
 	}
 
@@ -134,44 +138,70 @@ func runPackageInstall(cmd *cobra.Command, args []string) {
 	}
 
 	if len(toInstall) == 0 {
-		fmt.Println(ui.Success("Todos los paquetes ya están instalados"))
+		fmt.Println(ui.Success(i18n.T("common.all_installed")))
+		offerAlternates(alternates)
 		return
 	}
 
-	// Crear opciones para multi-select (preseleccionadas)
-	var options []huh.Option[string]
-	finalSelection := make([]string, len(toInstall))
-	copy(finalSelection, toInstall) // Preseleccionar todos
-	for _, pkg := range toInstall {
-		options = append(options, huh.NewOption(pkg, pkg))
+	// Mostrar los paquetes agrupados por sección (preseleccionados)
+	finalSelection, err := pickPackages(i18n.T("common.select_packages"), groups, toInstall, "pacman")
+	if err != nil {
+		fmt.Println(ui.Warning(i18n.T("common.install_cancelled")))
+		return
 	}
 
-	// Mostrar lista multi-select preseleccionada
-	form3 := ui.NewForm(
-		huh.NewGroup(
-			huh.NewMultiSelect[string]().
-				Title(fmt.Sprintf("Selecciona paquetes a instalar (%d disponibles)", len(toInstall))).
-				Description("Todos los paquetes están preseleccionados. Deselecciona los que no deseas instalar.").
-				Options(options...).
-				Value(&finalSelection),
-		),
-	)
-
-	if err := form3.Run(); err != nil {
-		fmt.Println(ui.Warning("Instalación cancelada"))
+	if len(finalSelection) == 0 {
+		fmt.Println(ui.Warning(i18n.T("common.none_selected")))
 		return
 	}
 
-	if len(finalSelection) == 0 {
-		fmt.Println(ui.Warning("No se seleccionaron paquetes para instalar"))
+	if !prepareInstall("pacman", finalSelection) {
 		return
 	}
 
 	// Instalar todos los paquetes seleccionados en una sola corrida
 	if err := packages.InstallAllPackages(installer, finalSelection); err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error: %v", err)))
+		fmt.Println(ui.Error(i18n.T("common.error", err)))
+		return
+	}
+
+	fmt.Println(ui.Success(i18n.T("common.install_done")))
+	offerAlternates(alternates)
+}
+
+// offerAlternates ofrece instalar las herramientas de una lista compartida que
+// en esta distro no vienen del gestor de paquetes nativo
+func offerAlternates(alternates []packages.Resolution) {
+	pending := packages.PendingAlternates(alternates)
+	if len(pending) == 0 {
+		return
+	}
+
+	fmt.Println(ui.Info(i18n.T("package.alternates", len(pending))))
+	for _, res := range pending {
+		fmt.Println(ui.ListItem(fmt.Sprintf("%s (%s: %s)", res.Tool, res.Source, res.Package)))
+	}
+
+	var confirm bool
+	form := ui.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(i18n.T("package.alternates_confirm", len(pending))).
+				Affirmative(i18n.T("common.install")).
+				Negative(i18n.T("package.skip")).
+				Value(&confirm),
+		),
+	)
+
+	if err := form.RunConfirm(&confirm); err != nil || !confirm {
+		fmt.Println(ui.Dim(i18n.T("package.alternates_skipped")))
+		return
+	}
+
+	if err := packages.InstallAlternates(pending); err != nil {
+		fmt.Println(ui.Error(i18n.T("common.error", err)))
 		return
 	}
 
-	fmt.Println(ui.Success("Instalación completada"))
+	fmt.Println(ui.Success(i18n.T("package.alternates_installed")))
 }
@@ -3,21 +3,22 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
 
 	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/packages"
 	"orgmos/internal/ui"
 	"orgmos/internal/utils"
 )
 
 var paruCmd = &cobra.Command{
-	Use:   "paru",
-	Short: "Instalar Paru AUR Helper",
-	Long:  `Instala Paru, un ayudante AUR rápido y simple escrito en Rust.`,
-	Run:   runParuInstall,
+	Use:         "paru",
+	Short:       i18n.Key("paru.short"),
+	Long:        i18n.Key("paru.long"),
+	Run:         runParuInstall,
+	Annotations: requiresArch,
 }
 
 func init() {
@@ -25,11 +26,11 @@ func init() {
 }
 
 func runParuInstall(cmd *cobra.Command, args []string) {
-	fmt.Println(ui.Title("Instalación de Paru AUR Helper"))
+	fmt.Println(ui.Title(i18n.T("paru.title")))
 
 	// Verificar si ya está instalado
 	if packages.CheckParuInstalled() {
-		fmt.Println(ui.Success("Paru ya está instalado"))
+		fmt.Println(ui.Success(i18n.T("paru.already")))
 		output, _ := utils.RunCommandSilent("paru", "--version")
 		fmt.Println(ui.Info(output))
 		return
@@ -40,56 +41,53 @@ func runParuInstall(cmd *cobra.Command, args []string) {
 	form := ui.NewForm(
 		huh.NewGroup(
 			huh.NewConfirm().
-				Title("Instalar Paru AUR Helper").
-				Description("Paru es necesario para instalar paquetes desde AUR.\n\nPasos:\n  1. Instalar base-devel y git\n  2. Clonar repositorio de Paru\n  3. Compilar e instalar").
-				Affirmative("Instalar").
-				Negative("Cancelar").
+				Title(i18n.T("paru.short")).
+				Description(i18n.T("paru.confirm_desc")).
+				Affirmative(i18n.T("common.install")).
+				Negative(i18n.T("common.cancel")).
 				Value(&confirm),
 		),
 	)
 
-	if err := form.Run(); err != nil || !confirm {
-		fmt.Println(ui.Warning("Instalación cancelada"))
+	if err := form.RunConfirm(&confirm); err != nil || !confirm {
+		fmt.Println(ui.Warning(i18n.T("common.install_cancelled")))
 		return
 	}
 
 	// Paso 1: Instalar dependencias
-	fmt.Println(ui.Info("Instalando dependencias (base-devel, git)..."))
+	fmt.Println(ui.Info(i18n.T("paru.installing_deps")))
 	if err := utils.RunCommandWithSudo("pacman", "-S", "--needed", "--noconfirm", "base-devel", "git"); err != nil {
-		fmt.Println(ui.Error("Error instalando dependencias"))
+		fmt.Println(ui.Error(i18n.T("packages.paru_deps_failed")))
 		return
 	}
 
 	// Paso 2: Clonar repositorio
 	tmpDir := "/tmp/paru-install"
-	fmt.Println(ui.Info("Clonando repositorio de Paru..."))
+	fmt.Println(ui.Info(i18n.T("paru.cloning")))
 
 	// Limpiar directorio temporal si existe
 	os.RemoveAll(tmpDir)
+	// Si se interrumpe la compilación, no dejar el directorio temporal
+	defer utils.OnCancel(func() { os.RemoveAll(tmpDir) })()
 
-	if err := utils.RunCommand("git", "clone", "https://aur.archlinux.org/paru.git", tmpDir); err != nil {
-		fmt.Println(ui.Error("Error clonando repositorio"))
+	if err := utils.RunUserCommand("git", "clone", "https://aur.archlinux.org/paru.git", tmpDir); err != nil {
+		fmt.Println(ui.Error(i18n.T("packages.paru_clone_failed")))
 		return
 	}
 
 	// Paso 3: Compilar e instalar
-	fmt.Println(ui.Info("Compilando e instalando Paru..."))
+	fmt.Println(ui.Info(i18n.T("paru.building")))
 	oldDir, _ := os.Getwd()
 	defer os.Chdir(oldDir)
 
 	if err := os.Chdir(tmpDir); err != nil {
-		fmt.Println(ui.Error("Error cambiando directorio"))
+		fmt.Println(ui.Error(i18n.T("packages.paru_chdir_failed")))
 		return
 	}
 
 	// Ejecutar makepkg -si
-	makepkgCmd := exec.Command("makepkg", "-si", "--noconfirm")
-	makepkgCmd.Stdout = os.Stdout
-	makepkgCmd.Stderr = os.Stderr
-	makepkgCmd.Stdin = os.Stdin
-
-	if err := makepkgCmd.Run(); err != nil {
-		fmt.Println(ui.Error("Error compilando Paru"))
+	if err := utils.RunUserCommand("makepkg", "-si", "--noconfirm"); err != nil {
+		fmt.Println(ui.Error(i18n.T("packages.paru_build_failed")))
 		os.Chdir(oldDir)
 		os.RemoveAll(tmpDir)
 		return
@@ -101,10 +99,10 @@ func runParuInstall(cmd *cobra.Command, args []string) {
 
 	// Verificar instalación
 	if packages.CheckParuInstalled() {
-		fmt.Println(ui.Success("Paru instalado correctamente"))
+		fmt.Println(ui.Success(i18n.T("packages.paru_installed")))
 		output, _ := utils.RunCommandSilent("paru", "--version")
 		fmt.Println(ui.Info(output))
 	} else {
-		fmt.Println(ui.Error("Paru no se pudo instalar correctamente"))
+		fmt.Println(ui.Error(i18n.T("paru.failed")))
 	}
 }
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/packages"
+	"orgmos/internal/ui"
+)
+
+// pickPackages muestra los paquetes pendientes agrupados por sección, con su
+// descripción, origen y tamaño. manager indica a quién consultar la información
+// ("pacman", "apt", "dnf", "flatpak"); vacío para no consultar nada.
+// Todos los paquetes aparecen preseleccionados.
+func pickPackages(title string, groups []packages.PackageGroup, toInstall []string, manager string) ([]string, error) {
+	var info map[string]packages.PackageInfo
+	if manager != "" {
+		ui.RunSpinner(i18n.T("picker.loading"), func() {
+			info = packages.FetchPackageInfo(manager, toInstall)
+		})
+	}
+
+	pending := make(map[string]bool, len(toInstall))
+	for _, pkg := range toInstall {
+		pending[pkg] = true
+	}
+
+	var items []ui.PickerItem
+	for _, group := range groups {
+		for _, pkg := range group.Packages {
+			if !pending[pkg] {
+				continue
+			}
+			// Evitar duplicados si el paquete aparece en varios grupos
+			delete(pending, pkg)
+
+			pkgInfo := info[pkg]
+			var detail []string
+			if pkgInfo.Source != "" {
+				detail = append(detail, pkgInfo.Source)
+			}
+			if pkgInfo.InstalledSize != "" {
+				detail = append(detail, pkgInfo.InstalledSize)
+			}
+
+			items = append(items, ui.PickerItem{
+				Group:       group.Name,
+				Value:       pkg,
+				Label:       pkg,
+				Description: pkgInfo.Description,
+				Detail:      strings.Join(detail, " · "),
+				Selected:    true,
+			})
+		}
+	}
+
+	return ui.RunPicker(
+		i18n.T("picker.title", title, len(items)),
+		i18n.T("picker.desc"),
+		items,
+	)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/huh"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/preflight"
+	"orgmos/internal/ui"
+	"orgmos/internal/utils"
+)
+
+var skipPreflight bool
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&skipPreflight, "no-preflight", false, i18n.Key("preflight.flag"))
+}
+
+// prepareInstall ejecuta las verificaciones previas, muestra el resumen y valida
+// los privilegios una sola vez para toda la instalación.
+// Retorna false si hay problemas bloqueantes y el usuario decide no continuar.
+func prepareInstall(manager string, pkgs []string) bool {
+	if !skipPreflight && !runPreflight(manager, pkgs) {
+		return false
+	}
+
+	// Flatpak instala en el sistema a través de polkit, no necesita sudo
+	if manager == "flatpak" {
+		return true
+	}
+
+	if err := utils.EnsurePrivileges(); err != nil {
+		fmt.Println(ui.Error(err.Error()))
+		return false
+	}
+	return true
+}
+
+// runPreflight ejecuta las verificaciones previas y muestra el resumen.
+// Retorna false si hay problemas bloqueantes y el usuario decide no continuar.
+func runPreflight(manager string, pkgs []string) bool {
+	var report preflight.Report
+	ui.RunSpinner(i18n.T("preflight.checking"), func() {
+		report = preflight.Run(manager, pkgs)
+	})
+
+	report.Print()
+
+	if !report.Blocked() {
+		return true
+	}
+
+	var proceed bool
+	form := ui.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(i18n.T("preflight.blocked")).
+				Description(i18n.T("preflight.blocked_desc")).
+				Affirmative(i18n.T("preflight.continue")).
+				Negative(i18n.T("common.cancel")).
+				Value(&proceed),
+		),
+	)
+
+	// Los problemas bloqueantes no se aceptan con --yes: sin terminal se cancela
+	if err := form.Run(); err != nil || !proceed {
+		fmt.Println(ui.Warning(i18n.T("common.install_cancelled")))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+
+	"orgmos/internal/history"
+	"orgmos/internal/i18n"
+	"orgmos/internal/packages"
+	"orgmos/internal/ui"
+	"orgmos/internal/utils"
+)
+
+var removeCmd = &cobra.Command{
+	Use:   "remove [lista] [grupo]",
+	Short: i18n.Key("remove.short"),
+	Long:  i18n.Key("remove.long"),
+	Args:  cobra.MaximumNArgs(2),
+	Run:   runRemove,
+}
+
+func init() {
+	rootCmd.AddCommand(removeCmd)
+}
+
+// removeTarget es la lista elegida para desinstalar
+type removeTarget struct {
+	name    string
+	file    string
+	listDir string
+	manager string
+	files   []string // Todas las listas del mismo directorio
+}
+
+func runRemove(cmd *cobra.Command, args []string) {
+	fmt.Println(ui.Title(i18n.T("remove.title")))
+
+	targets := removeTargets()
+	if len(targets) == 0 {
+		fmt.Println(ui.Error(i18n.T("common.no_lists")))
+		return
+	}
+
+	var target removeTarget
+	if len(args) > 0 {
+		found := false
+		for _, t := range targets {
+			if t.name == args[0] || t.file == args[0] {
+				target, found = t, true
+				break
+			}
+		}
+		if !found {
+			fmt.Println(ui.Error(i18n.T("remove.unknown_list", args[0])))
+			return
+		}
+	} else {
+		var options []huh.Option[string]
+		for _, t := range targets {
+			options = append(options, huh.NewOption(fmt.Sprintf("%s (%s)", t.name, t.file), t.name))
+		}
+		var choice string
+		if err := ui.NewForm(
+			huh.NewGroup(
+				huh.NewSelect[string]().
+					Title(i18n.T("remove.select_list")).
+					Options(options...).
+					Value(&choice),
+			),
+		).Run(); err != nil {
+			fmt.Println(ui.Warning(i18n.T("common.operation_cancelled")))
+			return
+		}
+		for _, t := range targets {
+			if t.name == choice {
+				target = t
+			}
+		}
+	}
+
+	groupFilter := ""
+	if len(args) > 1 {
+		groupFilter = args[1]
+	}
+
+	var groups []packages.PackageGroup
+	var installed map[string]bool
+	var otherLists map[string][]string
+	var requiredBy map[string][]string
+	var loadErr error
+
+	ui.RunSpinner(i18n.T("common.checking_installed"), func() {
+		groups, _, loadErr = packages.LoadDistroList(target.listDir, target.manager, target.file)
+		if loadErr != nil {
+			return
+		}
+		if groupFilter != "" {
+			groups = filterGroup(groups, groupFilter)
+		}
+
+		var all []string
+		for _, g := range groups {
+			all = append(all, g.Packages...)
+		}
+		installed = packages.CheckInstalledWith(target.manager, all)
+
+		var present []string
+		for _, pkg := range all {
+			if installed[pkg] {
+				present = append(present, pkg)
+			}
+		}
+		otherLists = packageLists(target.listDir, target.manager, target.files, target.file)
+		requiredBy = packages.RequiredBy(target.manager, present)
+	})
+
+	if loadErr != nil {
+		fmt.Println(ui.Error(i18n.T("common.load_packages_failed", loadErr)))
+		return
+	}
+	if len(groups) == 0 {
+		fmt.Println(ui.Error(i18n.T("remove.unknown_group", groupFilter, target.file)))
+		return
+	}
+
+	var items []ui.PickerItem
+	var warned int
+	for _, g := range groups {
+		for _, pkg := range g.Packages {
+			if !installed[pkg] {
+				continue
+			}
+
+			var warnings []string
+			if lists := otherLists[pkg]; len(lists) > 0 {
+				warnings = append(warnings, i18n.T("remove.also_in", strings.Join(lists, ", ")))
+			}
+			if users := requiredBy[pkg]; len(users) > 0 {
+				warnings = append(warnings, i18n.T("remove.required_by", strings.Join(users, ", ")))
+			}
+			if len(warnings) > 0 {
+				warned++
+			}
+
+			items = append(items, ui.PickerItem{
+				Group:    g.Name,
+				Value:    pkg,
+				Label:    pkg,
+				Detail:   strings.Join(warnings, " · "),
+				Selected: len(warnings) == 0,
+			})
+		}
+	}
+
+	if len(items) == 0 {
+		fmt.Println(ui.Success(i18n.T("remove.none_installed")))
+		return
+	}
+	if warned > 0 {
+		fmt.Println(ui.Warning(i18n.T("remove.warned", warned)))
+	}
+
+	selected, err := ui.RunPicker(
+		i18n.T("remove.picker_title", target.file, len(items)),
+		i18n.T("remove.picker_desc"),
+		items,
+	)
+	if err != nil {
+		if !errors.Is(err, huh.ErrUserAborted) && !errors.Is(err, ui.ErrNoTerminal) {
+			fmt.Println(ui.Error(i18n.T("common.error", err)))
+		}
+		fmt.Println(ui.Warning(i18n.T("common.operation_cancelled")))
+		return
+	}
+	if len(selected) == 0 {
+		fmt.Println(ui.Warning(i18n.T("remove.none_selected")))
+		return
+	}
+
+	var confirm bool
+	ui.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(i18n.T("remove.confirm", len(selected))).
+				Affirmative(i18n.T("remove.remove")).
+				Negative(i18n.T("common.cancel")).
+				Value(&confirm),
+		),
+	).RunConfirm(&confirm)
+
+	if !confirm {
+		fmt.Println(ui.Warning(i18n.T("common.operation_cancelled")))
+		return
+	}
+
+	removeErr := packages.RemovePackages(target.manager, selected)
+
+	entry := history.Entry{
+		Action:   "remove",
+		Manager:  target.manager,
+		List:     target.file,
+		Group:    groupFilter,
+		Packages: selected,
+		Success:  removeErr == nil,
+	}
+	if removeErr != nil {
+		entry.Error = removeErr.Error()
+	}
+	if err := history.Record(entry); err != nil {
+		fmt.Println(ui.Dim(i18n.T("common.history_failed", err)))
+	}
+
+	if removeErr != nil {
+		fmt.Println(ui.Error(i18n.T("common.error", removeErr)))
+		return
+	}
+	fmt.Println(ui.Success(i18n.T("common.removed_n", len(selected))))
+}
+
+// removeTargets retorna las listas de la distro detectada y la de Flatpak
+func removeTargets() []removeTarget {
+	var targets []removeTarget
+
+	if d, ok := detectedDistro(); ok {
+		files := listFiles(d)
+		for _, l := range d.Lists {
+			targets = append(targets, removeTarget{
+				name:    l.Command,
+				file:    l.File,
+				listDir: d.Dir(),
+				manager: d.PackageManager,
+				files:   files,
+			})
+		}
+	}
+
+	if utils.CommandExists("flatpak") {
+		targets = append(targets, removeTarget{
+			name:    "flatpak",
+			file:    "pkg_flatpak.lst",
+			listDir: "flatpak",
+			manager: "flatpak",
+			files:   []string{"pkg_flatpak.lst"},
+		})
+	}
+
+	return targets
+}
+
+// filterGroup retorna solo el grupo con el nombre indicado (sin distinguir mayúsculas)
+func filterGroup(groups []packages.PackageGroup, name string) []packages.PackageGroup {
+	for _, g := range groups {
+		if strings.EqualFold(g.Name, name) {
+			return []packages.PackageGroup{g}
+		}
+	}
+	return nil
+}
@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/ui"
+	"orgmos/internal/utils"
 )
 
 const Version = "1.05"
@@ -16,40 +21,95 @@ var (
 	cfgFile string
 	rootCmd = &cobra.Command{
 		Use:     "orgmos",
-		Short:   "ORGMOS - Sistema de configuración multi-distro",
+		Short:   i18n.Key("root.short"),
 		Version: Version,
-		Long: `ORGMOS es una herramienta de configuración y automatización
-para sistemas Arch Linux, Debian y Ubuntu.
-
-Usa 'orgmos menu' para acceder al menú interactivo o ejecuta
-'orgmos [comando] --help' para ver la ayuda de un comando específico.`,
+		Long:    i18n.Key("root.long"),
 		Run: func(cmd *cobra.Command, args []string) {
 			runMenu(cmd, args)
 		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireJSONSupport(cmd, args); err != nil {
+				return err
+			}
+			return requireDistro(cmd, args)
+		},
 	}
 )
 
+var (
+	plainOutput bool // --plain
+	assumeYes   bool // --yes
+)
+
 // Execute ejecuta el comando raíz
 func Execute() error {
-	return rootCmd.Execute()
+	registerDistroCommands()
+
+	// Log de los comandos que se ejecuten en esta corrida ('orgmos logs')
+	utils.StartRunLog(Version, os.Args[1:])
+
+	// Ctrl+C y SIGTERM cancelan este contexto y con él los procesos en curso
+	ctx := utils.HandleSignals(context.Background())
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		return err
+	}
+
+	// Un error reportado (en texto o como evento JSON) también cambia el código
+	// de salida, ej: para que el panel o un script sepan que la acción falló
+	if ui.ErrorEvents() > 0 {
+		return fmt.Errorf(i18n.T("root.errors"), ui.ErrorEvents())
+	}
+	return nil
 }
 
 func init() {
-	cobra.OnInitialize(initConfig)
+	cobra.OnInitialize(loadConfig)
 
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "archivo de configuración")
+	// --help y los errores de uso se muestran antes de OnInitialize: también
+	// cargan el perfil para que la ayuda salga en su idioma
+	help := rootCmd.HelpFunc()
+	rootCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		loadConfig()
+		help(cmd, args)
+	})
+	usage := rootCmd.UsageFunc()
+	rootCmd.SetUsageFunc(func(cmd *cobra.Command) error {
+		loadConfig()
+		return usage(cmd)
+	})
+
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", i18n.Key("root.flag_config"))
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", ui.OutputText, i18n.Key("root.flag_output"))
+	rootCmd.PersistentFlags().BoolVar(&plainOutput, "plain", false, i18n.Key("root.flag_plain"))
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, i18n.Key("root.flag_yes"))
 
 	// Cambiar template de versión
 	rootCmd.SetVersionTemplate("ORGMOS v{{.Version}}\n")
 }
 
+// configOnce evita leer el perfil dos veces cuando la ayuda se muestra después de OnInitialize
+var configOnce sync.Once
+
+// loadConfig lee el perfil y aplica sus opciones una sola vez
+func loadConfig() {
+	configOnce.Do(initConfig)
+}
+
 func initConfig() {
+	// Colores, símbolos y formularios según la terminal
+	ui.SetupTerminal(plainOutput, assumeYes)
+
+	if err := ui.SetOutputMode(outputFormat); err != nil {
+		fmt.Fprintln(os.Stderr, ui.Error(err.Error()))
+		os.Exit(1)
+	}
+
 	if cfgFile != "" {
 		viper.SetConfigFile(cfgFile)
 	} else {
-		home, err := os.UserHomeDir()
+		home, err := utils.GetHomeDir()
 		if err != nil {
-			fmt.Println(ui.Error(err.Error()))
+			fmt.Fprintln(ui.TextOut(), ui.Error(err.Error()))
 			os.Exit(1)
 		}
 
@@ -58,6 +118,59 @@ func initConfig() {
 		viper.SetConfigName(".orgmos")
 	}
 
-	viper.AutomaticEnv()
 	viper.ReadInConfig()
+
+	// Idioma de los mensajes, ej: language: en (por defecto el de LANG). Se lee
+	// antes de AutomaticEnv, que tomaría LANGUAGE de GNU en lugar del perfil;
+	// solo ORGMOS_LANGUAGE puede reemplazarlo desde el entorno.
+	viper.BindEnv("language", "ORGMOS_LANGUAGE")
+	if lang := viper.GetString("language"); lang != "" {
+		if err := i18n.SetLanguage(lang); err != nil {
+			fmt.Fprintln(ui.TextOut(), ui.Warning(err.Error()))
+		}
+	}
+	localizeCommands()
+
+	viper.AutomaticEnv()
+
+	// Los avisos van a stderr para no mezclarse con los documentos JSON
+	if distroLoadErr != nil {
+		fmt.Fprintln(os.Stderr, ui.Warning(i18n.T("distro.load_failed", distroLoadErr)))
+	}
+
+	// Colores de la interfaz, ej: theme: tokyonight (sin tema, los de pywal si hay)
+	if err := ui.LoadTheme(viper.GetString("theme"), viper.GetStringMapString("colors"), utils.GetPywalColorsFile()); err != nil {
+		fmt.Fprintln(ui.TextOut(), ui.Warning(err.Error()))
+	}
+
+	// Herramienta de elevación: "sudo", "doas" o "run0" (vacío para detectarla)
+	utils.SetPrivilegeTool(viper.GetString("privilege"))
+
+	// Tiempos límite por operación, ej: timeouts.git: 10m (0 para desactivarlo)
+	for _, op := range []string{utils.OpQuery, utils.OpGit, utils.OpDownload, utils.OpInstall} {
+		if key := "timeouts." + op; viper.IsSet(key) {
+			utils.SetTimeout(op, viper.GetDuration(key))
+		}
+	}
+}
+
+// localizeCommands traduce los textos de ayuda de todos los comandos. Se definen
+// con i18n.Key porque el idioma del perfil se conoce recién en initConfig.
+func localizeCommands() {
+	localizeDistroCommands()
+	localizeCommand(rootCmd)
+}
+
+// localizeCommand traduce Short, Long y las flags de cmd y sus subcomandos
+func localizeCommand(cmd *cobra.Command) {
+	cmd.Short = i18n.T(cmd.Short)
+	cmd.Long = i18n.T(cmd.Long)
+
+	translate := func(f *pflag.Flag) { f.Usage = i18n.T(f.Usage) }
+	cmd.Flags().VisitAll(translate)
+	cmd.PersistentFlags().VisitAll(translate)
+
+	for _, sub := range cmd.Commands() {
+		localizeCommand(sub)
+	}
 }
@@ -3,10 +3,9 @@ package main
 import (
 	"fmt"
 
-	"github.com/charmbracelet/huh"
-	"github.com/charmbracelet/huh/spinner"
 	"github.com/spf13/cobra"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/packages"
 	"orgmos/internal/ui"
 	"orgmos/internal/utils"
@@ -14,8 +13,8 @@ import (
 
 var scriptsCmd = &cobra.Command{
 	Use:   "scripts",
-	Short: "Ejecutar scripts de instalación",
-	Long:  `Ejecuta los comandos listados en packages/scripts/extra.lst para instalar aplicaciones específicas.`,
+	Short: i18n.Key("scripts.short"),
+	Long:  i18n.Key("scripts.long"),
 	Run:   runScriptsInstall,
 }
 
@@ -24,31 +23,28 @@ func init() {
 }
 
 func runScriptsInstall(cmd *cobra.Command, args []string) {
-	fmt.Println(ui.Title("Scripts de instalación"))
+	fmt.Println(ui.Title(i18n.T("common.scripts")))
 
 	// Clonar/actualizar dotfiles con spinner
 	if err := utils.CloneOrUpdateDotfilesWithSpinner(); err != nil {
-		fmt.Println(ui.Warning(fmt.Sprintf("No se pudo clonar/actualizar dotfiles: %v", err)))
-		fmt.Println(ui.Warning("Se intentará continuar con el repositorio existente si está disponible"))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_failed", err)))
+		fmt.Println(ui.Warning(i18n.T("common.dotfiles_fallback")))
 	}
 
 	var groups []packages.PackageGroup
 	var parseErr error
 
-	spinner.New().
-		Title("Cargando scripts...").
-		Action(func() {
-			groups, parseErr = packages.ParseLST("scripts", "extra.lst")
-		}).
-		Run()
+	ui.RunSpinner(i18n.T("scripts.loading"), func() {
+		groups, parseErr = packages.ParseLST("scripts", "extra.lst")
+	})
 
 	if parseErr != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error cargando scripts: %v", parseErr)))
+		fmt.Println(ui.Error(i18n.T("scripts.load_failed", parseErr)))
 		return
 	}
 
 	if len(groups) == 0 {
-		fmt.Println(ui.Warning("No se encontraron scripts para ejecutar"))
+		fmt.Println(ui.Warning(i18n.T("scripts.none")))
 		return
 	}
 
@@ -58,48 +54,31 @@ func runScriptsInstall(cmd *cobra.Command, args []string) {
 	}
 
 	if len(scripts) == 0 {
-		fmt.Println(ui.Warning("No hay comandos definidos en la lista de scripts"))
+		fmt.Println(ui.Warning(i18n.T("scripts.empty")))
 		return
 	}
 
-	// Crear opciones para multi-select (preseleccionadas)
-	var options []huh.Option[string]
-	finalSelection := make([]string, len(scripts))
-	copy(finalSelection, scripts) // Preseleccionar todos
-	for _, script := range scripts {
-		options = append(options, huh.NewOption(script, script))
-	}
-
-	// Mostrar lista multi-select preseleccionada
-	form := ui.NewForm(
-		huh.NewGroup(
-			huh.NewMultiSelect[string]().
-				Title(fmt.Sprintf("Selecciona scripts a ejecutar (%d disponibles)", len(scripts))).
-				Description("Todos los scripts están preseleccionados. Deselecciona los que no deseas ejecutar.").
-				Options(options...).
-				Value(&finalSelection),
-		),
-	)
-
-	if err := form.Run(); err != nil {
-		fmt.Println(ui.Warning("Ejecución cancelada"))
+	// Mostrar los scripts agrupados por sección (preseleccionados)
+	finalSelection, err := pickPackages(i18n.T("scripts.select"), groups, scripts, "")
+	if err != nil {
+		fmt.Println(ui.Warning(i18n.T("scripts.cancelled")))
 		return
 	}
 
 	if len(finalSelection) == 0 {
-		fmt.Println(ui.Warning("No se seleccionaron scripts para ejecutar"))
+		fmt.Println(ui.Warning(i18n.T("scripts.none_selected")))
 		return
 	}
 
 	// Ejecutar solo los scripts seleccionados
 	for idx, script := range finalSelection {
-		fmt.Println(ui.Info(fmt.Sprintf("(%d/%d) Ejecutando: %s", idx+1, len(finalSelection), script)))
+		fmt.Println(ui.Info(i18n.T("scripts.running", idx+1, len(finalSelection), script)))
 		if err := utils.RunCommand("bash", "-c", script); err != nil {
-			fmt.Println(ui.Error(fmt.Sprintf("Error ejecutando script %d: %v", idx+1, err)))
+			fmt.Println(ui.Error(i18n.T("scripts.failed", idx+1, err)))
 			return
 		}
 	}
 
-	fmt.Println(ui.Success("Scripts ejecutados correctamente"))
+	fmt.Println(ui.Success(i18n.T("scripts.done")))
 }
 
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/spf13/cobra"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/packages"
+	"orgmos/internal/ui"
+	"orgmos/internal/utils"
+)
+
+var statusCmd = &cobra.Command{
+	Use:         "status",
+	Short:       i18n.Key("status.short"),
+	Long:        i18n.Key("status.long"),
+	Run:         runStatus,
+	Annotations: supportsJSON,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
+
+// statusGroup es la cobertura de una sección de una lista
+type statusGroup struct {
+	Name      string   `json:"name"`
+	Installed int      `json:"installed"`
+	Missing   []string `json:"missing"`
+}
+
+// statusList es la cobertura de una lista .lst completa
+type statusList struct {
+	Name      string        `json:"name"`
+	File      string        `json:"file"`
+	Manager   string        `json:"manager"`
+	Installed int           `json:"installed"`
+	Total     int           `json:"total"`
+	Groups    []statusGroup `json:"groups,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// statusReport es el resultado completo de 'orgmos status'
+type statusReport struct {
+	Schema           string       `json:"schema"`
+	Distro           string       `json:"distro"`
+	PackageManager   string       `json:"package_manager"`
+	DotfilesRevision string       `json:"dotfiles_revision,omitempty"`
+	DotfilesDate     string       `json:"dotfiles_date,omitempty"`
+	LastConfig       string       `json:"last_config,omitempty"`
+	Wallpapers       int          `json:"wallpapers"`
+	Scripts          int          `json:"scripts"`
+	Lists            []statusList `json:"lists"`
+}
+
+func runStatus(cmd *cobra.Command, args []string) {
+	var report statusReport
+
+	if ui.JSONOutput() {
+		printDocument(collectStatus())
+		return
+	}
+
+	fmt.Println(ui.Title(i18n.T("status.title")))
+
+	ui.RunSpinner(i18n.T("common.checking_installed"), func() {
+		report = collectStatus()
+	})
+
+	printStatus(report)
+}
+
+// collectStatus carga todas las listas de la distro detectada y verifica qué está instalado
+func collectStatus() statusReport {
+	report := statusReport{
+		Schema:         schemaStatus,
+		Distro:         systemName(),
+		PackageManager: systemPackageManager(),
+	}
+
+	if revision, date, err := utils.GetDotfilesRevision(); err == nil {
+		report.DotfilesRevision = revision
+		report.DotfilesDate = date
+	}
+
+	if stamp, ok := utils.LastConfigApplied(); ok {
+		report.LastConfig = stamp.Format("2006-01-02 15:04")
+	}
+
+	homeDir, _ := utils.GetHomeDir()
+	if wallpapers, err := listWallpapers(filepath.Join(homeDir, "Pictures", "Wallpapers")); err == nil {
+		report.Wallpapers = len(wallpapers)
+	}
+
+	if d, ok := detectedDistro(); ok {
+		for _, l := range d.Lists {
+			groups, _, err := packages.LoadDistroList(d.Dir(), d.PackageManager, l.File)
+			if os.IsNotExist(err) {
+				// Lista declarada en el descriptor pero ausente en el repo dotfiles
+				continue
+			}
+			report.Lists = append(report.Lists, listStatus(l.Command, l.File, d.PackageManager, groups, err))
+		}
+	}
+
+	if utils.CommandExists("flatpak") {
+		groups, err := packages.ParseLST("flatpak", "pkg_flatpak.lst")
+		if !os.IsNotExist(err) {
+			report.Lists = append(report.Lists, listStatus("flatpak", "pkg_flatpak.lst", "flatpak", groups, err))
+		}
+	}
+
+	// Los scripts no se pueden verificar; solo se cuentan
+	if scripts, err := packages.GetAllPackagesLST("scripts", "extra.lst"); err == nil {
+		report.Scripts = len(scripts)
+	}
+
+	return report
+}
+
+// listStatus calcula instalados/faltantes por grupo de una lista
+func listStatus(name string, file string, manager string, groups []packages.PackageGroup, loadErr error) statusList {
+	status := statusList{Name: name, File: file, Manager: manager}
+	if loadErr != nil {
+		status.Error = loadErr.Error()
+		return status
+	}
+
+	var allPkgs []string
+	for _, g := range groups {
+		allPkgs = append(allPkgs, g.Packages...)
+	}
+	installed := packages.CheckInstalledWith(manager, allPkgs)
+
+	for _, g := range groups {
+		group := statusGroup{Name: g.Name, Missing: []string{}}
+		for _, pkg := range g.Packages {
+			if installed[pkg] {
+				group.Installed++
+			} else {
+				group.Missing = append(group.Missing, pkg)
+			}
+		}
+		status.Installed += group.Installed
+		status.Total += len(g.Packages)
+		status.Groups = append(status.Groups, group)
+	}
+
+	return status
+}
+
+// printStatus muestra el resumen y una tabla de cobertura por lista y grupo
+func printStatus(report statusReport) {
+	fmt.Println(ui.Info(i18n.T("status.distro", report.Distro, report.PackageManager)))
+
+	if report.DotfilesRevision != "" {
+		fmt.Println(ui.Info(i18n.T("status.dotfiles", report.DotfilesRevision, report.DotfilesDate)))
+	} else {
+		fmt.Println(ui.Warning(i18n.T("status.no_dotfiles")))
+	}
+
+	if report.LastConfig != "" {
+		fmt.Println(ui.Info(i18n.T("status.last_config", report.LastConfig)))
+	} else {
+		fmt.Println(ui.Warning(i18n.T("status.never_config")))
+	}
+
+	if report.Wallpapers > 0 {
+		fmt.Println(ui.Info(i18n.T("status.wallpapers", report.Wallpapers)))
+	} else {
+		fmt.Println(ui.Warning(i18n.T("status.no_wallpapers")))
+	}
+
+	if report.Scripts > 0 {
+		fmt.Println(ui.Dim(i18n.T("status.scripts", report.Scripts)))
+	}
+	fmt.Println()
+
+	if len(report.Lists) == 0 {
+		fmt.Println(ui.Warning(i18n.T("common.no_lists")))
+		return
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(ui.SkyBlue).Bold(true).Padding(0, 1)
+	cellStyle := lipgloss.NewStyle().Padding(0, 1)
+	listStyle := cellStyle.Foreground(ui.White).Bold(true)
+
+	var rows [][]string
+	var listRows []int
+	for _, l := range report.Lists {
+		listRows = append(listRows, len(rows)+1)
+		if l.Error != "" {
+			rows = append(rows, []string{l.Name, "error: " + l.Error, "", "", ""})
+			continue
+		}
+		rows = append(rows, []string{l.Name, l.File, strconv.Itoa(l.Installed), strconv.Itoa(l.Total - l.Installed), coverage(l.Installed, l.Total)})
+		for _, g := range l.Groups {
+			total := g.Installed + len(g.Missing)
+			rows = append(rows, []string{"", "  " + g.Name, strconv.Itoa(g.Installed), strconv.Itoa(len(g.Missing)), coverage(g.Installed, total)})
+		}
+	}
+
+	isListRow := make(map[int]bool)
+	for _, row := range listRows {
+		isListRow[row] = true
+	}
+
+	t := ui.NewTable().
+		Headers(i18n.T("common.list"), i18n.T("status.group"), i18n.T("status.installed"), i18n.T("status.missing"), i18n.T("status.coverage")).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			switch {
+			case row == table.HeaderRow:
+				return headerStyle
+			case isListRow[row+1]:
+				return listStyle
+			default:
+				return cellStyle
+			}
+		})
+
+	fmt.Println(t.Render())
+}
+
+// coverage formatea el porcentaje de paquetes instalados
+func coverage(installed int, total int) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d%%", installed*100/total)
+}
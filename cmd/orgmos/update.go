@@ -8,7 +8,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/ui"
+	"orgmos/internal/utils"
 )
 
 const binURL = "https://custom.or-gm.com/orgmos"
@@ -16,8 +18,8 @@ const installScriptURL = "https://custom.or-gm.com/orgmos.sh"
 
 var updateCmd = &cobra.Command{
 	Use:   "update",
-	Short: "Actualizar ORGMOS",
-	Long:  `Descarga y actualiza el binario de ORGMOS a la última versión.`,
+	Short: i18n.Key("update.short"),
+	Long:  i18n.Key("update.long"),
 	Run:   runUpdate,
 }
 
@@ -26,11 +28,11 @@ func init() {
 }
 
 func runUpdate(cmd *cobra.Command, args []string) {
-	fmt.Println(ui.Title("Actualizando ORGMOS"))
+	fmt.Println(ui.Title(i18n.T("update.title")))
 
-	homeDir, err := os.UserHomeDir()
+	homeDir, err := utils.GetHomeDir()
 	if err != nil {
-		fmt.Println(ui.Error(fmt.Sprintf("Error obteniendo directorio home: %v", err)))
+		fmt.Println(ui.Error(i18n.T("common.home_error", err)))
 		return
 	}
 
@@ -39,7 +41,7 @@ func runUpdate(cmd *cobra.Command, args []string) {
 
 	// Verificar si el binario existe
 	if _, err := os.Stat(binPath); os.IsNotExist(err) {
-		fmt.Println(ui.Warning("Binario orgmos no encontrado. Ejecuta el script de instalación:"))
+		fmt.Println(ui.Warning(i18n.T("update.not_found")))
 		fmt.Println(ui.Info(fmt.Sprintf("curl -fsSL %s | sh", installScriptURL)))
 		return
 	}
@@ -60,53 +62,56 @@ func runUpdate(cmd *cobra.Command, args []string) {
 
 	if binInUse {
 		// Binario en uso, descargar a /tmp y mostrar instrucciones
-		fmt.Println(ui.Warning("El binario orgmos está en uso y no puede ser reemplazado automáticamente."))
-		fmt.Println(ui.Info("Descargando nueva versión a /tmp/orgmos_update..."))
+		fmt.Println(ui.Warning(i18n.T("update.busy")))
+		fmt.Println(ui.Info(i18n.T("update.downloading_tmp")))
 
 		// Descargar a /tmp
-		var downloadCmd *exec.Cmd
+		var downloadArgs []string
 		if _, err := exec.LookPath("curl"); err == nil {
-			downloadCmd = exec.Command("curl", "-fsSL", binURL, "-o", tmpBinPath)
+			downloadArgs = []string{"curl", "-fsSL", binURL, "-o", tmpBinPath}
 		} else if _, err := exec.LookPath("wget"); err == nil {
-			downloadCmd = exec.Command("wget", "-q", binURL, "-O", tmpBinPath)
+			downloadArgs = []string{"wget", "-q", binURL, "-O", tmpBinPath}
 		} else {
-			fmt.Println(ui.Error("Se requiere curl o wget para descargar el binario"))
+			fmt.Println(ui.Error(i18n.T("update.no_downloader")))
 			return
 		}
 
-		if err := downloadCmd.Run(); err != nil {
-			fmt.Println(ui.Error(fmt.Sprintf("Error descargando binario: %v", err)))
+		// La descarga se cancela con Ctrl+C o al superar el tiempo límite
+		if _, err := utils.RunCommandSilent(downloadArgs[0], downloadArgs[1:]...); err != nil {
+			fmt.Println(ui.Error(i18n.T("update.download_failed", err)))
 			return
 		}
 
 		// Hacer ejecutable
 		os.Chmod(tmpBinPath, 0755)
 
-		fmt.Println(ui.Success("Binario descargado a /tmp/orgmos_update"))
+		fmt.Println(ui.Success(i18n.T("update.downloaded_tmp")))
 		fmt.Println()
-		fmt.Println(ui.Info("Para completar la actualización:"))
-		fmt.Println(ui.Dim("1. Cierra todas las instancias de orgmos"))
-		fmt.Println(ui.Dim(fmt.Sprintf("2. Ejecuta: mv %s %s", tmpBinPath, binPath)))
+		fmt.Println(ui.Info(i18n.T("update.finish")))
+		fmt.Println(ui.Dim(i18n.T("update.step_close")))
+		fmt.Println(ui.Dim(i18n.T("update.step_move", tmpBinPath, binPath)))
 		fmt.Println()
-		fmt.Println(ui.Info("O descarga manualmente desde:"))
+		fmt.Println(ui.Info(i18n.T("update.manual")))
 		fmt.Println(ui.Dim(binURL))
 	} else {
 		// Binario no en uso, reemplazar directamente
-		fmt.Println(ui.Info("Descargando nueva versión..."))
+		fmt.Println(ui.Info(i18n.T("update.downloading")))
 
-		// Descargar a /tmp primero
-		var downloadCmd *exec.Cmd
+		// Descargar a /tmp primero; si se interrumpe, no dejar el archivo a medias
+		defer utils.OnCancel(func() { os.Remove(tmpBinPath) })()
+		var downloadArgs []string
 		if _, err := exec.LookPath("curl"); err == nil {
-			downloadCmd = exec.Command("curl", "-fsSL", binURL, "-o", tmpBinPath)
+			downloadArgs = []string{"curl", "-fsSL", binURL, "-o", tmpBinPath}
 		} else if _, err := exec.LookPath("wget"); err == nil {
-			downloadCmd = exec.Command("wget", "-q", binURL, "-O", tmpBinPath)
+			downloadArgs = []string{"wget", "-q", binURL, "-O", tmpBinPath}
 		} else {
-			fmt.Println(ui.Error("Se requiere curl o wget para descargar el binario"))
+			fmt.Println(ui.Error(i18n.T("update.no_downloader")))
 			return
 		}
 
-		if err := downloadCmd.Run(); err != nil {
-			fmt.Println(ui.Error(fmt.Sprintf("Error descargando binario: %v", err)))
+		// La descarga se cancela con Ctrl+C o al superar el tiempo límite
+		if _, err := utils.RunCommandSilent(downloadArgs[0], downloadArgs[1:]...); err != nil {
+			fmt.Println(ui.Error(i18n.T("update.download_failed", err)))
 			return
 		}
 
@@ -115,12 +120,13 @@ func runUpdate(cmd *cobra.Command, args []string) {
 
 		// Reemplazar binario
 		if err := os.Rename(tmpBinPath, binPath); err != nil {
-			fmt.Println(ui.Error(fmt.Sprintf("Error reemplazando binario: %v", err)))
-			fmt.Println(ui.Info("El binario descargado está en /tmp/orgmos_update"))
+			fmt.Println(ui.Error(i18n.T("update.replace_failed", err)))
+			fmt.Println(ui.Info(i18n.T("update.left_tmp")))
 			return
 		}
+		utils.ChownToUser(binPath)
 
-		fmt.Println(ui.Success("ORGMOS actualizado correctamente"))
+		fmt.Println(ui.Success(i18n.T("update.done")))
 	}
 }
 
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/ui"
+)
+
+var versionCmd = &cobra.Command{
+	Use:         "version",
+	Short:       i18n.Key("version.short"),
+	Args:        cobra.NoArgs,
+	Run:         runVersion,
+	Annotations: supportsJSON,
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+}
+
+// versionReport es el resultado de 'orgmos version --output json'
+type versionReport struct {
+	Schema         string `json:"schema"`
+	Version        string `json:"version"`
+	GoVersion      string `json:"go_version"`
+	Platform       string `json:"platform"` // GOOS/GOARCH
+	Distro         string `json:"distro,omitempty"`
+	PackageManager string `json:"package_manager,omitempty"`
+}
+
+func runVersion(cmd *cobra.Command, args []string) {
+	report := versionReport{
+		Schema:    schemaVersion,
+		Version:   Version,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if d, ok := detectedDistro(); ok {
+		report.Distro = d.Name
+		report.PackageManager = d.PackageManager
+	}
+
+	if ui.JSONOutput() {
+		printDocument(report)
+		return
+	}
+
+	fmt.Printf("ORGMOS v%s\n", report.Version)
+}
@@ -0,0 +1,250 @@
+package distro
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/packages"
+	"orgmos/internal/utils"
+)
+
+//go:embed descriptors/*.toml
+var builtinFS embed.FS
+
+// Descriptor describe una distribución soportada: cómo detectarla, qué gestor de
+// paquetes usa, qué listas ofrece y qué muestra su submenú
+type Descriptor struct {
+	Name           string      `toml:"name"`            // Nombre del subcomando, ej: "debian"
+	Title          string      `toml:"title"`           // Nombre legible, ej: "Debian"
+	IDs            []string    `toml:"ids"`             // Valores de ID en /etc/os-release
+	IDLike         []string    `toml:"id_like"`         // Valores aceptados en ID_LIKE
+	PackageManager string      `toml:"package_manager"` // "pacman", "apt" o "dnf"
+	ListDir        string      `toml:"list_dir"`        // Directorio en packages/ (por defecto Name)
+	Lists          []List      `toml:"lists"`
+	Menu           []MenuEntry `toml:"menu"`
+
+	Builtin bool `toml:"-"` // true si viene compilado en orgmos
+}
+
+// List es una lista .lst expuesta como subcomando
+type List struct {
+	Command     string `toml:"command"` // Nombre del subcomando, ej: "base"
+	File        string `toml:"file"`    // Archivo .lst dentro de ListDir
+	Title       string `toml:"title"`
+	Description string `toml:"description"`
+
+	Discovered bool `toml:"-"` // true si se encontró en el repo dotfiles sin estar declarada
+}
+
+// MenuEntry es una opción del submenú: una lista del descriptor o una acción integrada
+type MenuEntry struct {
+	Title  string `toml:"title"`
+	List   string `toml:"list"`   // Command de una de las listas
+	Action string `toml:"action"` // Acción integrada de orgmos (niri, i3, network, ...)
+}
+
+// Dir retorna el directorio de listas del descriptor
+func (d Descriptor) Dir() string {
+	if d.ListDir != "" {
+		return d.ListDir
+	}
+	return d.Name
+}
+
+// FindList busca una lista por su nombre de comando
+func (d Descriptor) FindList(command string) (List, bool) {
+	for _, l := range d.Lists {
+		if l.Command == command {
+			return l, true
+		}
+	}
+	return List{}, false
+}
+
+// MenuEntries retorna las opciones del submenú. Si el descriptor no define
+// ninguna, se genera una por lista; las listas descubiertas siempre se agregan.
+func (d Descriptor) MenuEntries() []MenuEntry {
+	entries := append([]MenuEntry(nil), d.Menu...)
+
+	referenced := make(map[string]bool)
+	for _, entry := range entries {
+		referenced[entry.List] = true
+	}
+
+	for _, l := range d.Lists {
+		if referenced[l.Command] || (len(d.Menu) > 0 && !l.Discovered) {
+			continue
+		}
+		entries = append(entries, MenuEntry{Title: l.Title, List: l.Command})
+	}
+	return entries
+}
+
+// Localize traduce los títulos y descripciones de un descriptor integrado, que
+// son claves de los catálogos de i18n. Se llama cuando ya se conoce el idioma
+// del perfil; los descriptores del usuario y las listas descubiertas se dejan igual.
+func (d *Descriptor) Localize() {
+	if !d.Builtin {
+		return
+	}
+	for i, l := range d.Lists {
+		if l.Discovered {
+			continue
+		}
+		d.Lists[i].Title = i18n.T(l.Title)
+		d.Lists[i].Description = i18n.T(l.Description)
+	}
+	for i, entry := range d.Menu {
+		d.Menu[i].Title = i18n.T(entry.Title)
+	}
+}
+
+// addDiscoveredLists agrega las listas de packages/<dir>/*.lst que el descriptor no declara
+func (d *Descriptor) addDiscoveredLists() {
+	found, err := packages.DiscoverLists(d.Dir())
+	if err != nil {
+		return
+	}
+
+	declared := make(map[string]bool)
+	commands := make(map[string]bool)
+	for _, l := range d.Lists {
+		declared[l.File] = true
+		commands[l.Command] = true
+	}
+
+	for _, info := range found {
+		if declared[info.File] || commands[info.Name] {
+			continue
+		}
+		d.Lists = append(d.Lists, List{
+			Command:     info.Name,
+			File:        info.File,
+			Title:       info.Title,
+			Description: info.Description,
+			Discovered:  true,
+		})
+		commands[info.Name] = true
+	}
+}
+
+// UserDescriptorsDir retorna el directorio de descriptores del repositorio dotfiles
+func UserDescriptorsDir() string {
+	return filepath.Join(utils.GetDotfilesDir(), "packages", "distros")
+}
+
+// Load carga los descriptores integrados y los de packages/distros/*.toml del
+// repositorio dotfiles. Un descriptor del usuario con el mismo nombre reemplaza al integrado.
+// Los descriptores del usuario van primero para que tengan prioridad al detectar.
+// A cada descriptor se le agregan las listas .lst encontradas en su directorio.
+func Load() ([]Descriptor, error) {
+	return loadFrom(UserDescriptorsDir())
+}
+
+// loadFrom es Load con los descriptores del usuario en userDir
+func loadFrom(userDir string) ([]Descriptor, error) {
+	var descriptors []Descriptor
+	seen := make(map[string]bool)
+	var loadErr error
+
+	userFiles, _ := filepath.Glob(filepath.Join(userDir, "*.toml"))
+	sort.Strings(userFiles)
+	for _, path := range userFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			loadErr = err
+			continue
+		}
+		desc, err := parseDescriptor(data, path)
+		if err != nil {
+			loadErr = err
+			continue
+		}
+		if seen[desc.Name] {
+			continue
+		}
+		seen[desc.Name] = true
+		descriptors = append(descriptors, desc)
+	}
+
+	builtinFiles, _ := builtinFS.ReadDir("descriptors")
+	for _, entry := range builtinFiles {
+		data, err := builtinFS.ReadFile("descriptors/" + entry.Name())
+		if err != nil {
+			continue
+		}
+		desc, err := parseDescriptor(data, entry.Name())
+		if err != nil || seen[desc.Name] {
+			continue
+		}
+		desc.Builtin = true
+		seen[desc.Name] = true
+		descriptors = append(descriptors, desc)
+	}
+
+	for i := range descriptors {
+		descriptors[i].addDiscoveredLists()
+	}
+
+	return descriptors, loadErr
+}
+
+func parseDescriptor(data []byte, source string) (Descriptor, error) {
+	var desc Descriptor
+	if _, err := toml.Decode(string(data), &desc); err != nil {
+		return desc, fmt.Errorf(i18n.T("common.read_error"), source, err)
+	}
+
+	if desc.Name == "" {
+		desc.Name = strings.TrimSuffix(filepath.Base(source), ".toml")
+	}
+	if desc.Title == "" {
+		desc.Title = desc.Name
+	}
+
+	switch desc.PackageManager {
+	case "pacman", "apt", "dnf":
+	default:
+		return desc, fmt.Errorf(i18n.T("distro.unsupported_manager"), source, desc.PackageManager)
+	}
+
+	return desc, nil
+}
+
+// Match busca el descriptor que corresponde a un ID/ID_LIKE de os-release.
+// Una coincidencia exacta de ID tiene prioridad sobre ID_LIKE, y los valores de
+// ID_LIKE se prueban en su orden: "ubuntu debian" elige ubuntu antes que debian.
+func Match(descriptors []Descriptor, id string, idLike string) (Descriptor, bool) {
+	for _, d := range descriptors {
+		for _, candidate := range d.IDs {
+			if strings.EqualFold(candidate, id) {
+				return d, true
+			}
+		}
+	}
+
+	for _, like := range strings.Fields(idLike) {
+		for _, d := range descriptors {
+			for _, candidate := range d.IDLike {
+				if strings.EqualFold(candidate, like) {
+					return d, true
+				}
+			}
+		}
+	}
+
+	return Descriptor{}, false
+}
+
+// Detect retorna el descriptor de la distribución en ejecución
+func Detect(descriptors []Descriptor) (Descriptor, bool) {
+	id, idLike := utils.ReadOSRelease()
+	return Match(descriptors, id, idLike)
+}
@@ -0,0 +1,114 @@
+package distro
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	descriptors, err := loadFrom(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id, idLike string
+		want       string // Nombre del descriptor, "" si no hay coincidencia
+	}{
+		{"arch", "", "arch"},
+		{"endeavouros", "arch", "arch"},
+		{"linuxmint", "ubuntu debian", "ubuntu"},
+		{"neon", "ubuntu debian", "ubuntu"},
+		{"pop", "ubuntu debian", "ubuntu"},
+		{"lmde", "debian", "debian"},
+		{"rocky", "rhel centos fedora", "fedora"},
+		{"DEBIAN", "", "debian"},
+		{"opensuse-tumbleweed", "opensuse suse", ""},
+	}
+
+	for _, tt := range tests {
+		d, ok := Match(descriptors, tt.id, tt.idLike)
+		if got := map[bool]string{true: d.Name}[ok]; got != tt.want {
+			t.Errorf("Match(%q, %q) = %q, se esperaba %q", tt.id, tt.idLike, got, tt.want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		// Reemplaza al integrado y se detecta antes que él
+		"debian.toml": "title = \"Debian (mío)\"\nids = [\"debian\"]\npackage_manager = \"apt\"\n",
+		// Descriptor nuevo: el nombre sale del archivo
+		"mint.toml": "title = \"Mint\"\nids = [\"linuxmint\"]\nid_like = [\"ubuntu\"]\npackage_manager = \"apt\"\nlist_dir = \"mint\"\n",
+		"bad.toml":  "package_manager = \"zypper\"\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	descriptors, err := loadFrom(dir)
+	if err == nil || !strings.Contains(err.Error(), "bad.toml") {
+		t.Errorf("error %v, se esperaba uno de bad.toml", err)
+	}
+
+	byName := make(map[string]Descriptor)
+	var names []string
+	for _, d := range descriptors {
+		byName[d.Name] = d
+		names = append(names, d.Name)
+	}
+	if want := "debian mint arch fedora ubuntu"; strings.Join(names, " ") != want {
+		t.Errorf("descriptores %v, se esperaba %s", names, want)
+	}
+	if d := byName["debian"]; d.Builtin || d.Title != "Debian (mío)" {
+		t.Errorf("debian = %+v, se esperaba el del usuario", d)
+	}
+	if d := byName["mint"]; d.Dir() != "mint" || d.PackageManager != "apt" {
+		t.Errorf("mint = %+v", d)
+	}
+	if d := byName["arch"]; !d.Builtin || len(d.Lists) == 0 {
+		t.Errorf("arch = %+v, se esperaba el integrado con sus listas", d)
+	}
+
+	// El descriptor del usuario tiene prioridad al detectar
+	if d, ok := Match(descriptors, "linuxmint", "ubuntu debian"); !ok || d.Name != "mint" {
+		t.Errorf("Match(linuxmint) = %q, se esperaba mint", d.Name)
+	}
+}
+
+// TestLocalize verifica que los textos de los descriptores integrados sean
+// claves existentes y que los del usuario no se toquen
+func TestLocalize(t *testing.T) {
+	descriptors, err := loadFrom(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range descriptors {
+		d.Localize()
+		for _, l := range d.Lists {
+			if l.Discovered {
+				continue
+			}
+			if strings.HasPrefix(l.Title, "descriptors.") || strings.HasPrefix(l.Description, "descriptors.") {
+				t.Errorf("%s/%s: falta traducir %q o %q", d.Name, l.Command, l.Title, l.Description)
+			}
+		}
+		for _, entry := range d.Menu {
+			if strings.HasPrefix(entry.Title, "descriptors.") {
+				t.Errorf("%s: falta traducir la opción %q", d.Name, entry.Title)
+			}
+		}
+	}
+
+	user := Descriptor{Lists: []List{{Command: "x", Title: "descriptors.base_title"}}}
+	user.Localize()
+	if user.Lists[0].Title != "descriptors.base_title" {
+		t.Errorf("se tradujo el título de un descriptor del usuario: %q", user.Lists[0].Title)
+	}
+}
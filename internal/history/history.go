@@ -0,0 +1,80 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"orgmos/internal/utils"
+)
+
+// historyFile es el registro de operaciones, una entrada JSON por línea
+const historyFile = "history.jsonl"
+
+// Entry es una operación sobre paquetes realizada por orgmos
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Action   string    `json:"action"` // "install" o "remove"
+	Manager  string    `json:"manager"`
+	List     string    `json:"list,omitempty"`
+	Group    string    `json:"group,omitempty"`
+	Packages []string  `json:"packages"`
+	Success  bool      `json:"success"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Path retorna la ruta del archivo de historial
+func Path() string {
+	return filepath.Join(utils.GetOrgmosStateDir(), historyFile)
+}
+
+// Record agrega una entrada al historial
+func Record(entry Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	if err := utils.MkdirAllForUser(filepath.Dir(Path()), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(Path(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	utils.ChownToUser(Path())
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+// Load lee todas las entradas del historial, de la más antigua a la más reciente.
+// Las líneas dañadas se ignoran.
+func Load() ([]Entry, error) {
+	file, err := os.Open(Path())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
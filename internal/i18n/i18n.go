@@ -0,0 +1,186 @@
+// Package i18n traduce los mensajes de orgmos. Cada idioma tiene un catálogo
+// en catalogs/<idioma>.toml con una tabla por área; la clave de un mensaje es
+// "<tabla>.<nombre>", ej: "common.install_cancelled".
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+)
+
+//go:embed catalogs/*.toml
+var catalogFS embed.FS
+
+// DefaultLanguage es el idioma que se usa si el del sistema no tiene catálogo
+const DefaultLanguage = "es"
+
+var (
+	loadOnce sync.Once
+	catalogs map[string]map[string]string
+
+	mu       sync.RWMutex
+	language string
+)
+
+// loadCatalogs lee los catálogos integrados. Un catálogo dañado es un error de
+// compilación de orgmos, así que se detiene en lugar de seguir sin mensajes.
+func loadCatalogs() {
+	loadOnce.Do(func() {
+		catalogs = make(map[string]map[string]string)
+
+		entries, err := catalogFS.ReadDir("catalogs")
+		if err != nil {
+			panic(fmt.Sprintf("i18n: no se pudieron leer los catálogos: %v", err))
+		}
+
+		for _, entry := range entries {
+			lang, ok := strings.CutSuffix(entry.Name(), ".toml")
+			if !ok {
+				continue
+			}
+
+			var raw map[string]any
+			if _, err := toml.DecodeFS(catalogFS, path.Join("catalogs", entry.Name()), &raw); err != nil {
+				panic(fmt.Sprintf("i18n: catálogo %s inválido: %v", entry.Name(), err))
+			}
+
+			messages := make(map[string]string)
+			flatten("", raw, messages)
+			catalogs[lang] = messages
+		}
+	})
+}
+
+// flatten convierte las tablas del TOML en claves "tabla.nombre"
+func flatten(prefix string, raw map[string]any, messages map[string]string) {
+	for key, value := range raw {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			messages[key] = v
+		case map[string]any:
+			flatten(key, v, messages)
+		}
+	}
+}
+
+// Languages retorna los idiomas que tienen catálogo
+func Languages() []string {
+	loadCatalogs()
+
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Messages retorna las claves y mensajes del catálogo de un idioma
+func Messages(lang string) map[string]string {
+	loadCatalogs()
+	return catalogs[lang]
+}
+
+// normalize reduce un locale al código de idioma: "en_US.UTF-8" → "en"
+func normalize(locale string) string {
+	lang := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(lang, "_.@-"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+// supported retorna el primer idioma con catálogo de una lista separada por
+// ':' (el formato de LANGUAGE), ej: "pt:en" → "en"
+func supported(locales string) (string, bool) {
+	loadCatalogs()
+
+	for _, locale := range strings.Split(locales, ":") {
+		lang := normalize(locale)
+		if _, ok := catalogs[lang]; ok {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// DetectLanguage obtiene el idioma de LC_ALL, LC_MESSAGES o LANG (el primero
+// que esté definido). Si no tiene catálogo se usa DefaultLanguage.
+func DetectLanguage() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(env)
+		if value == "" {
+			continue
+		}
+		if lang, ok := supported(value); ok {
+			return lang
+		}
+		break
+	}
+	return DefaultLanguage
+}
+
+// SetLanguage cambia el idioma de los mensajes. Acepta un código ("en"), un
+// locale ("en_US.UTF-8") o una lista separada por ':'.
+func SetLanguage(locales string) error {
+	lang, ok := supported(locales)
+	if !ok {
+		return fmt.Errorf(T("common.unsupported_language"), locales, strings.Join(Languages(), ", "))
+	}
+
+	mu.Lock()
+	language = lang
+	mu.Unlock()
+	return nil
+}
+
+// Language retorna el idioma actual
+func Language() string {
+	mu.RLock()
+	lang := language
+	mu.RUnlock()
+
+	if lang == "" {
+		lang = DetectLanguage()
+		mu.Lock()
+		language = lang
+		mu.Unlock()
+	}
+	return lang
+}
+
+// Key marca una clave que se traduce más tarde con T. Se usa en los textos
+// que se definen al iniciar, antes de conocer el idioma del perfil (ej: la
+// ayuda de los comandos); TestKeysExist también las verifica.
+func Key(key string) string {
+	return key
+}
+
+// T retorna el mensaje de key en el idioma actual, formateado con args como en
+// fmt.Sprintf. Si falta en el idioma actual se usa el de DefaultLanguage y si
+// no existe en ninguno se retorna la clave.
+func T(key string, args ...any) string {
+	loadCatalogs()
+
+	message, ok := catalogs[Language()][key]
+	if !ok {
+		if message, ok = catalogs[DefaultLanguage][key]; !ok {
+			message = key
+		}
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
@@ -0,0 +1,115 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// verbRe encuentra los verbos de formato de un mensaje (%s, %d, %q, %v, %w, %.1f, ...)
+var verbRe = regexp.MustCompile(`%[-+# 0]*[0-9]*(?:\.[0-9]+)?[a-zA-Z%]`)
+
+// keyRe encuentra las claves usadas en el código: i18n.T("tabla.nombre" o i18n.Key("tabla.nombre"
+var keyRe = regexp.MustCompile(`i18n\.(?:T|Key)\("([^"]+)"`)
+
+func verbs(message string) []string {
+	var found []string
+	for _, verb := range verbRe.FindAllString(message, -1) {
+		if verb != "%%" {
+			found = append(found, verb)
+		}
+	}
+	return found
+}
+
+// TestCatalogsMatch verifica que todos los catálogos tengan las mismas claves,
+// sin mensajes vacíos y con los mismos verbos de formato en el mismo orden
+func TestCatalogsMatch(t *testing.T) {
+	base := Messages(DefaultLanguage)
+	if len(base) == 0 {
+		t.Fatalf("el catálogo %s está vacío", DefaultLanguage)
+	}
+
+	for _, lang := range Languages() {
+		messages := Messages(lang)
+
+		for key, message := range messages {
+			if strings.TrimSpace(message) == "" {
+				t.Errorf("%s: %s está vacío", lang, key)
+			}
+			if _, ok := base[key]; !ok {
+				t.Errorf("%s: %s no existe en %s", lang, key, DefaultLanguage)
+			}
+		}
+
+		for key, message := range base {
+			translated, ok := messages[key]
+			if !ok {
+				t.Errorf("%s: falta %s", lang, key)
+				continue
+			}
+			if want, got := strings.Join(verbs(message), " "), strings.Join(verbs(translated), " "); want != got {
+				t.Errorf("%s: %s usa %q, se esperaba %q", lang, key, got, want)
+			}
+		}
+	}
+}
+
+// TestKeysExist verifica que cada clave usada en el código exista en todos los catálogos
+func TestKeysExist(t *testing.T) {
+	used := make(map[string]string)
+	for _, dir := range []string{"../../cmd", "../../internal"} {
+		err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return err
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			for _, match := range keyRe.FindAllStringSubmatch(string(data), -1) {
+				used[match[1]] = path
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(used) == 0 {
+		t.Fatal("no se encontraron llamadas a i18n.T")
+	}
+
+	for _, lang := range Languages() {
+		messages := Messages(lang)
+		for key, path := range used {
+			if _, ok := messages[key]; !ok {
+				t.Errorf("%s: falta %s (usada en %s)", lang, key, path)
+			}
+		}
+	}
+}
+
+func TestSetLanguage(t *testing.T) {
+	defer SetLanguage(DefaultLanguage)
+
+	for _, locale := range []string{"en", "en_US.UTF-8", "pt_BR:en"} {
+		if err := SetLanguage(locale); err != nil {
+			t.Errorf("SetLanguage(%q): %v", locale, err)
+		}
+		if Language() != "en" {
+			t.Errorf("SetLanguage(%q): idioma %q, se esperaba en", locale, Language())
+		}
+	}
+
+	if err := SetLanguage("xx"); err == nil {
+		t.Error("SetLanguage(\"xx\") debería fallar")
+	}
+	if got := T("no.existe"); got != "no.existe" {
+		t.Errorf("T de una clave inexistente retornó %q", got)
+	}
+}
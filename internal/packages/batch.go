@@ -0,0 +1,228 @@
+package packages
+
+import (
+	"fmt"
+	"strings"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/ui"
+)
+
+// maxConsecutiveFailures es la cantidad de paquetes individuales que pueden fallar
+// seguidos antes de asumir que el problema no es de los paquetes (lock, red, disco)
+// y omitir el resto
+const maxConsecutiveFailures = 5
+
+// maxFixAttempts es la cantidad de correcciones guiadas que se ofrecen antes de aislar paquetes
+const maxFixAttempts = 3
+
+// batchInstaller instala un conjunto de paquetes (con argumentos extra del gestor)
+// y retorna la salida capturada
+type batchInstaller func(packages []string, extraArgs []string) (string, error)
+
+// FailedPackage es un paquete que no se pudo instalar ni siquiera por separado
+type FailedPackage struct {
+	Name    string
+	Excerpt string // Líneas relevantes del error
+}
+
+// BatchResult resume una instalación con aislamiento de fallos
+type BatchResult struct {
+	Succeeded []string
+	Failed    []FailedPackage
+	Skipped   []string // No se intentaron por fallos repetidos
+}
+
+// installIsolated intenta instalar todo en una sola corrida. Si falla por una causa
+// conocida (lock, llavero, disco lleno...) ofrece corregirla y reintenta. Si sigue
+// fallando, reintenta por mitades hasta aislar los paquetes problemáticos, instala
+// el resto y muestra un reporte final.
+func installIsolated(manager string, packages []string, install batchInstaller) error {
+	if len(packages) == 0 {
+		return nil
+	}
+
+	var extraArgs []string
+	var excluded []FailedPackage
+
+	output, err := install(packages, extraArgs)
+	for attempt := 0; err != nil && attempt < maxFixAttempts; attempt++ {
+		diagnosis, ok := Diagnose(manager, output)
+		if !ok || !offerFix(diagnosis) {
+			break
+		}
+
+		extraArgs = append(extraArgs, diagnosis.ExtraArgs...)
+		if len(diagnosis.Exclude) > 0 {
+			packages, excluded = excludePackages(packages, diagnosis.Exclude, excluded)
+			if len(packages) == 0 {
+				break
+			}
+		}
+
+		fmt.Println(ui.Info(i18n.T("packages.retrying")))
+		output, err = install(packages, extraArgs)
+	}
+
+	if err == nil || len(packages) == 0 {
+		if len(excluded) == 0 {
+			return nil
+		}
+		result := BatchResult{Failed: excluded}
+		if err == nil {
+			result.Succeeded = packages
+		}
+		result.Print()
+		return fmt.Errorf(i18n.T("packages.not_in_repos"), len(excluded))
+	}
+
+	if len(packages) == 1 && len(excluded) == 0 {
+		fmt.Println(ui.Error(i18n.T("packages.install_failed_one", packages[0])))
+		if diagnosis, ok := Diagnose(manager, output); ok {
+			fmt.Println(ui.Dim("    " + diagnosis.Problem))
+		}
+		if excerpt := errorExcerpt(output); excerpt != "" {
+			fmt.Println(ui.Dim(indent(excerpt, "    ")))
+		}
+		return err
+	}
+
+	fmt.Println(ui.Warning(i18n.T("packages.batch_failed")))
+
+	isolator := &batchIsolator{install: install, extraArgs: extraArgs}
+	isolator.result.Failed = excluded
+	isolator.bisect(packages)
+	isolator.result.Print()
+
+	if len(isolator.result.Failed) > 0 || len(isolator.result.Skipped) > 0 {
+		return fmt.Errorf(i18n.T("packages.install_failed_n"), len(isolator.result.Failed)+len(isolator.result.Skipped))
+	}
+	return nil
+}
+
+// batchIsolator reintenta un lote fallido dividiéndolo en mitades
+type batchIsolator struct {
+	install             batchInstaller
+	extraArgs           []string
+	result              BatchResult
+	consecutiveFailures int
+}
+
+func (b *batchIsolator) bisect(packages []string) {
+	if b.consecutiveFailures >= maxConsecutiveFailures {
+		b.result.Skipped = append(b.result.Skipped, packages...)
+		return
+	}
+
+	if len(packages) == 1 {
+		output, err := b.install(packages, b.extraArgs)
+		if err != nil {
+			b.consecutiveFailures++
+			b.result.Failed = append(b.result.Failed, FailedPackage{Name: packages[0], Excerpt: errorExcerpt(output)})
+			return
+		}
+		b.consecutiveFailures = 0
+		b.result.Succeeded = append(b.result.Succeeded, packages[0])
+		return
+	}
+
+	mid := len(packages) / 2
+	for _, half := range [][]string{packages[:mid], packages[mid:]} {
+		if b.consecutiveFailures >= maxConsecutiveFailures {
+			b.result.Skipped = append(b.result.Skipped, half...)
+			continue
+		}
+
+		if len(half) == 1 {
+			fmt.Println(ui.Info(i18n.T("packages.retry_single", half[0])))
+			b.bisect(half)
+			continue
+		}
+
+		fmt.Println(ui.Info(i18n.T("packages.retry_many", len(half), SummarizePackages(half))))
+		if _, err := b.install(half, b.extraArgs); err == nil {
+			b.consecutiveFailures = 0
+			b.result.Succeeded = append(b.result.Succeeded, half...)
+			continue
+		}
+		b.bisect(half)
+	}
+}
+
+// excludePackages quita del lote los paquetes indicados y los agrega a los fallidos
+func excludePackages(packages []string, exclude []string, failed []FailedPackage) ([]string, []FailedPackage) {
+	drop := make(map[string]bool)
+	for _, pkg := range exclude {
+		drop[pkg] = true
+	}
+
+	var kept []string
+	for _, pkg := range packages {
+		if drop[pkg] {
+			failed = append(failed, FailedPackage{Name: pkg, Excerpt: i18n.T("packages.not_in_enabled_repos")})
+			continue
+		}
+		kept = append(kept, pkg)
+	}
+	return kept, failed
+}
+
+// Print muestra el reporte de paquetes instalados, fallidos y omitidos
+func (r BatchResult) Print() {
+	fmt.Println()
+	fmt.Println(ui.Title(i18n.T("packages.result")))
+
+	if len(r.Succeeded) > 0 {
+		fmt.Println(ui.Success(i18n.T("packages.result_installed", len(r.Succeeded), strings.Join(r.Succeeded, ", "))))
+	}
+
+	if len(r.Failed) > 0 {
+		fmt.Println(ui.Error(i18n.T("packages.result_failed", len(r.Failed))))
+		for _, failed := range r.Failed {
+			fmt.Println(ui.ListItem(failed.Name))
+			if failed.Excerpt != "" {
+				fmt.Println(ui.Dim(indent(failed.Excerpt, "      ")))
+			}
+		}
+	}
+
+	if len(r.Skipped) > 0 {
+		fmt.Println(ui.Warning(i18n.T("packages.result_skipped", len(r.Skipped), strings.Join(r.Skipped, ", "))))
+	}
+}
+
+// errorExcerpt extrae las últimas líneas de error de la salida de un gestor de paquetes
+func errorExcerpt(output string) string {
+	lines := splitLines(output)
+
+	var errors []string
+	for _, line := range lines {
+		lower := strings.ToLower(line)
+		if strings.HasPrefix(lower, "error") || strings.HasPrefix(lower, "e:") ||
+			strings.Contains(lower, "error:") || strings.Contains(lower, "fatal") ||
+			strings.Contains(lower, "failed") || strings.Contains(lower, "not found") {
+			errors = append(errors, line)
+		}
+	}
+	if len(errors) == 0 {
+		errors = lines
+	}
+
+	if len(errors) > 3 {
+		errors = errors[len(errors)-3:]
+	}
+	return strings.Join(errors, "\n")
+}
+
+// SummarizePackages lista los primeros paquetes y cuántos más hay
+func SummarizePackages(packages []string) string {
+	if len(packages) <= 4 {
+		return strings.Join(packages, ", ")
+	}
+	return i18n.T("packages.summary_more", strings.Join(packages[:4], ", "), len(packages)-4)
+}
+
+// indent agrega un prefijo a cada línea
+func indent(text string, prefix string) string {
+	return prefix + strings.ReplaceAll(text, "\n", "\n"+prefix)
+}
@@ -0,0 +1,142 @@
+package packages
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// fakeInstaller simula un gestor de paquetes: falla si el lote contiene algún
+// paquete de broken y registra cada lote que recibe
+func fakeInstaller(broken ...string) (batchInstaller, *[][]string) {
+	var calls [][]string
+	return func(packages []string, extraArgs []string) (string, error) {
+		calls = append(calls, slices.Clone(packages))
+		for _, pkg := range packages {
+			if slices.Contains(broken, pkg) {
+				return "error: target not found: " + pkg, errors.New("exit status 1")
+			}
+		}
+		return "", nil
+	}, &calls
+}
+
+func failedNames(failed []FailedPackage) []string {
+	var names []string
+	for _, f := range failed {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+func TestBisect(t *testing.T) {
+	tests := []struct {
+		name          string
+		packages      []string
+		broken        []string
+		wantSucceeded []string
+		wantFailed    []string
+		wantSkipped   []string
+	}{
+		{
+			name:          "un paquete roto",
+			packages:      []string{"a", "b", "c", "d"},
+			broken:        []string{"c"},
+			wantSucceeded: []string{"a", "b", "d"},
+			wantFailed:    []string{"c"},
+		},
+		{
+			name:          "varios rotos en mitades distintas",
+			packages:      []string{"a", "b", "c", "d", "e"},
+			broken:        []string{"a", "e"},
+			wantSucceeded: []string{"b", "c", "d"},
+			wantFailed:    []string{"a", "e"},
+		},
+		{
+			name:       "un solo paquete",
+			packages:   []string{"a"},
+			broken:     []string{"a"},
+			wantFailed: []string{"a"},
+		},
+		{
+			name:        "fallos seguidos omiten el resto",
+			packages:    []string{"a", "b", "c", "d", "e", "f", "g", "h"},
+			broken:      []string{"a", "b", "c", "d", "e", "f", "g", "h"},
+			wantFailed:  []string{"a", "b", "c", "d", "e"},
+			wantSkipped: []string{"f", "g", "h"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			install, _ := fakeInstaller(tt.broken...)
+			isolator := &batchIsolator{install: install}
+			isolator.bisect(tt.packages)
+
+			result := isolator.result
+			if !reflect.DeepEqual(result.Succeeded, tt.wantSucceeded) {
+				t.Errorf("instalados %v, se esperaba %v", result.Succeeded, tt.wantSucceeded)
+			}
+			if got := failedNames(result.Failed); !reflect.DeepEqual(got, tt.wantFailed) {
+				t.Errorf("fallidos %v, se esperaba %v", got, tt.wantFailed)
+			}
+			if !reflect.DeepEqual(result.Skipped, tt.wantSkipped) {
+				t.Errorf("omitidos %v, se esperaba %v", result.Skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+// stubOfferFix reemplaza la pregunta de OfferFix por la respuesta apply
+func stubOfferFix(t *testing.T, apply bool) {
+	t.Helper()
+	prev := offerFix
+	offerFix = func(Diagnosis) bool { return apply }
+	t.Cleanup(func() { offerFix = prev })
+}
+
+func TestInstallIsolated(t *testing.T) {
+	stubOfferFix(t, false)
+
+	install, calls := fakeInstaller()
+	if err := installIsolated("pacman", []string{"a", "b"}, install); err != nil {
+		t.Fatalf("sin paquetes rotos: %v", err)
+	}
+	if len(*calls) != 1 {
+		t.Errorf("sin paquetes rotos se esperaba una sola corrida, hubo %d", len(*calls))
+	}
+
+	// Sin aceptar la corrección, se aísla el paquete por mitades
+	install, calls = fakeInstaller("b")
+	if err := installIsolated("pacman", []string{"a", "b", "c"}, install); err == nil {
+		t.Error("con un paquete roto se esperaba un error")
+	}
+	if len(*calls) < 3 {
+		t.Errorf("se esperaba la bisección, hubo %d corridas", len(*calls))
+	}
+}
+
+func TestInstallIsolatedAppliesFix(t *testing.T) {
+	stubOfferFix(t, true)
+
+	// La corrección de "target not found" reintenta sin el paquete inexistente
+	install, calls := fakeInstaller("b")
+	if err := installIsolated("pacman", []string{"a", "b", "c"}, install); err == nil {
+		t.Error("con un paquete inexistente se esperaba un error")
+	}
+	want := [][]string{{"a", "b", "c"}, {"a", "c"}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("corridas %v, se esperaba %v", *calls, want)
+	}
+}
+
+func TestExcludePackages(t *testing.T) {
+	kept, failed := excludePackages([]string{"a", "b", "c"}, []string{"b", "x"}, nil)
+	if want := []string{"a", "c"}; !reflect.DeepEqual(kept, want) {
+		t.Errorf("quedan %v, se esperaba %v", kept, want)
+	}
+	if got := failedNames(failed); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("fallidos %v, se esperaba [b]", got)
+	}
+}
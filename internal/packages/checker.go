@@ -3,11 +3,12 @@ package packages
 import (
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/charmbracelet/huh"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/ui"
 	"orgmos/internal/utils"
 )
@@ -44,12 +45,40 @@ func CheckInstalledPacman(packages []string) map[string]bool {
 	return installed
 }
 
-// CheckInstalledApt verifica paquetes instalados con apt (Debian/Ubuntu)
+// CheckInstalledApt verifica paquetes instalados con apt (Debian/Ubuntu).
+// Lee /var/lib/dpkg/status directamente: solo cuenta "install ok installed"
+// y reconoce los nombres virtuales declarados en Provides.
 func CheckInstalledApt(packages []string) map[string]bool {
 	installed := make(map[string]bool)
 
-	// Obtener lista de paquetes instalados
-	output, err := utils.RunCommandSilent("dpkg-query", "-W", "-f=${Package}\n")
+	installedPkgs, err := DpkgInstalledSet()
+	if err != nil {
+		// Fallback a dpkg-query filtrando por estado "ii"
+		installedPkgs = make(map[string]bool)
+		output, err := utils.RunCommandSilent("dpkg-query", "-W", "-f=${db:Status-Abbrev} ${Package}\n")
+		if err != nil {
+			return installed
+		}
+		for _, line := range strings.Split(output, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 2 && fields[0] == "ii" {
+				installedPkgs[fields[1]] = true
+			}
+		}
+	}
+
+	for _, pkg := range packages {
+		installed[pkg] = installedPkgs[pkg]
+	}
+
+	return installed
+}
+
+// CheckInstalledRpm verifica paquetes instalados consultando la base de datos rpm (Fedora)
+func CheckInstalledRpm(packages []string) map[string]bool {
+	installed := make(map[string]bool)
+
+	output, err := utils.RunCommandSilent("rpm", "-qa", "--qf", "%{NAME}\n")
 	if err != nil {
 		return installed
 	}
@@ -62,15 +91,91 @@ func CheckInstalledApt(packages []string) map[string]bool {
 		}
 	}
 
+	var groups map[string]bool
 	for _, pkg := range packages {
+		// Los grupos (@grupo) no aparecen en rpm: se consultan a dnf
+		if id, ok := strings.CutPrefix(pkg, "@"); ok {
+			if groups == nil {
+				groups = installedDnfGroups()
+			}
+			installed[pkg] = groups[id]
+			continue
+		}
 		installed[pkg] = installedPkgs[pkg]
 	}
 
 	return installed
 }
 
+// installedDnfGroups retorna los IDs de los grupos de dnf instalados. dnf 4 con
+// --ids muestra "Nombre (id)"; dnf5 no conoce --ids y muestra una tabla con el
+// ID en la primera columna.
+func installedDnfGroups() map[string]bool {
+	output, err := utils.RunCommandSilent("dnf", "-q", "group", "list", "--installed", "--ids")
+	if err != nil {
+		output, err = utils.RunCommandSilent("dnf", "-q", "group", "list", "--installed")
+		if err != nil {
+			return make(map[string]bool)
+		}
+	}
+	return parseDnfGroups(output)
+}
+
+// parseDnfGroups lee los IDs de la salida de 'dnf group list', sin los títulos
+// de sección de dnf 4 ("Installed Groups:") ni la cabecera de la tabla de dnf5
+func parseDnfGroups(output string) map[string]bool {
+	groups := make(map[string]bool)
+	for _, line := range splitLines(output) {
+		if open := strings.LastIndex(line, "("); open >= 0 && strings.HasSuffix(line, ")") {
+			groups[line[open+1:len(line)-1]] = true
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if strings.HasSuffix(line, ":") || (fields[0] == "ID" && len(fields) > 1 && fields[1] == "Name") {
+			continue
+		}
+		groups[fields[0]] = true
+	}
+	return groups
+}
+
+// CheckInstalledWith verifica paquetes instalados usando el gestor indicado
+// ("pacman", "apt", "dnf" o "flatpak")
+func CheckInstalledWith(manager string, packages []string) map[string]bool {
+	switch manager {
+	case "pacman":
+		return CheckInstalledPacman(packages)
+	case "apt":
+		return CheckInstalledApt(packages)
+	case "dnf":
+		return CheckInstalledRpm(packages)
+	case "flatpak":
+		return CheckInstalledFlatpak(packages)
+	default:
+		return make(map[string]bool)
+	}
+}
+
+// CheckCoprEnabled verifica si un repositorio COPR (owner/project) ya está habilitado
+func CheckCoprEnabled(repo string) bool {
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) != 2 {
+		return false
+	}
+
+	pattern := fmt.Sprintf("/etc/yum.repos.d/_copr*%s:%s.repo", parts[0], parts[1])
+	matches, _ := filepath.Glob(pattern)
+	return len(matches) > 0
+}
+
 // GetAptPackageDescription obtiene la descripción de un paquete apt
 func GetAptPackageDescription(pkg string) string {
+	// Buscar primero en los índices locales de apt
+	if candidates, _ := ResolveAptCandidates([]string{pkg}); candidates[pkg].Description != "" {
+		return candidates[pkg].Description
+	}
+
 	output, err := utils.RunCommandSilent("apt-cache", "show", pkg)
 	if err == nil {
 		lines := strings.Split(output, "\n")
@@ -209,10 +314,10 @@ func OfferInstallParu() bool {
 	form := ui.NewForm(
 		huh.NewGroup(
 			huh.NewConfirm().
-				Title("Paru no está instalado").
-				Description("Paru es necesario para instalar paquetes desde AUR.\n¿Deseas instalarlo ahora?").
-				Affirmative("Sí, instalar").
-				Negative("No, cancelar").
+				Title(i18n.T("packages.paru_missing")).
+				Description(i18n.T("packages.paru_missing_desc")).
+				Affirmative(i18n.T("common.yes_install")).
+				Negative(i18n.T("common.no_cancel")).
 				Value(&install),
 		),
 	)
@@ -226,20 +331,22 @@ func OfferInstallParu() bool {
 	}
 
 	// Instalar paru
-	fmt.Println(ui.Info("Instalando Paru..."))
+	fmt.Println(ui.Info(i18n.T("packages.paru_installing")))
 
 	// Instalar dependencias
 	if err := utils.RunCommandWithSudo("pacman", "-S", "--needed", "--noconfirm", "base-devel", "git"); err != nil {
-		fmt.Println(ui.Error("Error instalando dependencias"))
+		fmt.Println(ui.Error(i18n.T("packages.paru_deps_failed")))
 		return false
 	}
 
 	// Clonar y compilar
 	tmpDir := "/tmp/paru-install"
 	os.RemoveAll(tmpDir)
+	// Si se interrumpe la compilación, no dejar el directorio temporal
+	defer utils.OnCancel(func() { os.RemoveAll(tmpDir) })()
 
-	if err := utils.RunCommand("git", "clone", "https://aur.archlinux.org/paru.git", tmpDir); err != nil {
-		fmt.Println(ui.Error("Error clonando repositorio"))
+	if err := utils.RunUserCommand("git", "clone", "https://aur.archlinux.org/paru.git", tmpDir); err != nil {
+		fmt.Println(ui.Error(i18n.T("packages.paru_clone_failed")))
 		return false
 	}
 
@@ -247,18 +354,13 @@ func OfferInstallParu() bool {
 	defer os.Chdir(oldDir)
 
 	if err := os.Chdir(tmpDir); err != nil {
-		fmt.Println(ui.Error("Error cambiando directorio"))
+		fmt.Println(ui.Error(i18n.T("packages.paru_chdir_failed")))
 		os.RemoveAll(tmpDir)
 		return false
 	}
 
-	cmd := exec.Command("makepkg", "-si", "--noconfirm")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-
-	if err := cmd.Run(); err != nil {
-		fmt.Println(ui.Error("Error compilando Paru"))
+	if err := utils.RunUserCommand("makepkg", "-si", "--noconfirm"); err != nil {
+		fmt.Println(ui.Error(i18n.T("packages.paru_build_failed")))
 		os.Chdir(oldDir)
 		os.RemoveAll(tmpDir)
 		return false
@@ -268,7 +370,7 @@ func OfferInstallParu() bool {
 	os.RemoveAll(tmpDir)
 
 	if CheckParuInstalled() {
-		fmt.Println(ui.Success("Paru instalado correctamente"))
+		fmt.Println(ui.Success(i18n.T("packages.paru_installed")))
 		return true
 	}
 
@@ -0,0 +1,38 @@
+package packages
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDnfGroups(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   map[string]bool
+	}{
+		{
+			name:   "dnf 4 con --ids",
+			output: "Installed Groups:\n   Development Tools (development-tools)\n   C Development Tools and Libraries (c-development)\n",
+			want:   map[string]bool{"development-tools": true, "c-development": true},
+		},
+		{
+			name:   "tabla de dnf5",
+			output: "ID                   Name                   Installed\ndevelopment-tools    Development Tools            yes\n",
+			want:   map[string]bool{"development-tools": true},
+		},
+		{
+			name:   "sin grupos",
+			output: "",
+			want:   map[string]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseDnfGroups(tt.output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("grupos %v, se esperaba %v", got, tt.want)
+			}
+		})
+	}
+}
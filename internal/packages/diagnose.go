@@ -0,0 +1,337 @@
+package packages
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/huh"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/ui"
+	"orgmos/internal/utils"
+)
+
+// Tipos de fallo conocidos de los gestores de paquetes
+const (
+	FailureDBLock          = "db-lock"
+	FailureKeyring         = "keyring"
+	FailureFileConflict    = "file-conflict"
+	FailureNotFound        = "not-found"
+	FailureDpkgInterrupted = "dpkg-interrupted"
+	FailureHeldPackages    = "held-packages"
+	FailureDiskFull        = "disk-full"
+	FailureRpmdb           = "rpmdb"
+)
+
+const pacmanLockPath = "/var/lib/pacman/db.lck"
+
+// Diagnosis describe un fallo reconocido y cómo corregirlo antes de reintentar
+type Diagnosis struct {
+	Kind    string
+	Problem string // Qué pasó, en palabras del usuario
+	Remedy  string // Qué hará la corrección
+
+	Fix       func() error // Acción previa al reintento (puede ser nil)
+	ExtraArgs []string     // Argumentos a agregar al reintentar
+	Exclude   []string     // Paquetes a quitar del reintento
+}
+
+var (
+	pacmanNotFoundRe = regexp.MustCompile(`target not found: (\S+)`)
+	aptNotFoundRe    = regexp.MustCompile(`Unable to locate package (\S+)`)
+	dnfNotFoundRe    = regexp.MustCompile(`No match for argument: (\S+)`)
+	pacmanConflictRe = regexp.MustCompile(`(\S+): (/\S+) exists in filesystem`)
+	aptConflictRe    = regexp.MustCompile(`trying to overwrite '([^']+)'`)
+)
+
+// diagnoseRule reconoce un tipo de fallo en la salida de un gestor; lower es la
+// salida en minúsculas
+type diagnoseRule func(output string, lower string) (Diagnosis, bool)
+
+// diagnoseRules son las reglas de cada gestor, en orden de prioridad. Cada gestor
+// tiene las suyas para no proponer, por ejemplo, actualizar el llavero de Arch
+// cuando apt falla por una firma.
+var diagnoseRules = map[string][]diagnoseRule{
+	"pacman":  {pacmanLocked, pacmanKeyring, pacmanConflict, diskFull("pacman"), notFound(pacmanNotFoundRe)},
+	"apt":     {aptLocked, dpkgInterrupted, dpkgConflict, aptHeld, diskFull("apt"), notFound(aptNotFoundRe)},
+	"dnf":     {rpmdbBroken, diskFull("dnf"), notFound(dnfNotFoundRe)},
+	"flatpak": {diskFull("flatpak")},
+}
+
+// Diagnose clasifica la salida de un gestor de paquetes que falló.
+// manager puede ser "pacman" (también paru/yay), "apt", "dnf" o "flatpak".
+func Diagnose(manager string, output string) (Diagnosis, bool) {
+	if manager == "paru" || manager == "yay" {
+		manager = "pacman"
+	}
+
+	lower := strings.ToLower(output)
+	for _, rule := range diagnoseRules[manager] {
+		if diagnosis, ok := rule(output, lower); ok {
+			return diagnosis, true
+		}
+	}
+	return Diagnosis{}, false
+}
+
+func pacmanLocked(output string, lower string) (Diagnosis, bool) {
+	if !strings.Contains(lower, "unable to lock database") && !strings.Contains(output, pacmanLockPath) {
+		return Diagnosis{}, false
+	}
+	return Diagnosis{
+		Kind:    FailureDBLock,
+		Problem: i18n.T("diagnose.pacman_locked", pacmanLockPath),
+		Remedy:  i18n.T("diagnose.pacman_locked_fix"),
+		Fix:     removeStalePacmanLock,
+	}, true
+}
+
+func pacmanKeyring(output string, lower string) (Diagnosis, bool) {
+	if !strings.Contains(lower, "invalid or corrupted package") &&
+		!strings.Contains(lower, "signature from") &&
+		!strings.Contains(lower, "unknown trust") &&
+		!strings.Contains(lower, "marginal trust") &&
+		!strings.Contains(lower, "could not be looked up remotely") {
+		return Diagnosis{}, false
+	}
+	return Diagnosis{
+		Kind:    FailureKeyring,
+		Problem: i18n.T("diagnose.keyring"),
+		Remedy:  i18n.T("diagnose.keyring_fix"),
+		Fix: func() error {
+			return utils.RunCommandWithSudo("pacman", "-Sy", "--noconfirm", "archlinux-keyring")
+		},
+	}, true
+}
+
+func pacmanConflict(output string, lower string) (Diagnosis, bool) {
+	if !strings.Contains(lower, "conflicting files") && !strings.Contains(lower, "exists in filesystem") {
+		return Diagnosis{}, false
+	}
+	var extra []string
+	for _, match := range pacmanConflictRe.FindAllStringSubmatch(output, -1) {
+		extra = append(extra, "--overwrite", match[2])
+	}
+	return Diagnosis{
+		Kind:      FailureFileConflict,
+		Problem:   i18n.T("diagnose.pacman_conflict", len(extra)/2),
+		Remedy:    i18n.T("diagnose.pacman_conflict_fix"),
+		ExtraArgs: extra,
+	}, len(extra) > 0
+}
+
+func aptLocked(output string, lower string) (Diagnosis, bool) {
+	if !strings.Contains(lower, "could not get lock") &&
+		!strings.Contains(lower, "unable to acquire the dpkg frontend lock") &&
+		!strings.Contains(lower, "/var/lib/dpkg/lock-frontend") {
+		return Diagnosis{}, false
+	}
+	return Diagnosis{
+		Kind:    FailureDBLock,
+		Problem: i18n.T("diagnose.apt_locked"),
+		Remedy:  i18n.T("diagnose.apt_locked_fix"),
+		Fix:     waitForAptLock,
+	}, true
+}
+
+func dpkgInterrupted(output string, lower string) (Diagnosis, bool) {
+	if !strings.Contains(lower, "dpkg was interrupted") {
+		return Diagnosis{}, false
+	}
+	return Diagnosis{
+		Kind:    FailureDpkgInterrupted,
+		Problem: i18n.T("diagnose.dpkg_interrupted"),
+		Remedy:  i18n.T("diagnose.dpkg_interrupted_fix"),
+		Fix: func() error {
+			return utils.RunCommandWithSudo("dpkg", "--configure", "-a")
+		},
+	}, true
+}
+
+func dpkgConflict(output string, lower string) (Diagnosis, bool) {
+	if !aptConflictRe.MatchString(output) {
+		return Diagnosis{}, false
+	}
+	return Diagnosis{
+		Kind:      FailureFileConflict,
+		Problem:   i18n.T("diagnose.dpkg_conflict"),
+		Remedy:    i18n.T("diagnose.dpkg_conflict_fix"),
+		ExtraArgs: []string{"-o", "Dpkg::Options::=--force-overwrite"},
+	}, true
+}
+
+func aptHeld(output string, lower string) (Diagnosis, bool) {
+	if !strings.Contains(lower, "held broken packages") && !strings.Contains(lower, "have held packages") {
+		return Diagnosis{}, false
+	}
+	return diagnoseHeld(), true
+}
+
+func rpmdbBroken(output string, lower string) (Diagnosis, bool) {
+	if !strings.Contains(lower, "rpmdb open failed") &&
+		!strings.Contains(lower, "error: rpmdb:") &&
+		!strings.Contains(lower, "cannot open packages database") {
+		return Diagnosis{}, false
+	}
+	return Diagnosis{
+		Kind:    FailureRpmdb,
+		Problem: i18n.T("diagnose.rpmdb"),
+		Remedy:  i18n.T("diagnose.rpmdb_fix"),
+		Fix: func() error {
+			return utils.RunCommandWithSudo("rpm", "--rebuilddb")
+		},
+	}, true
+}
+
+// diskFull reconoce la falta de espacio; la corrección limpia la caché de manager
+func diskFull(manager string) diagnoseRule {
+	return func(output string, lower string) (Diagnosis, bool) {
+		if !strings.Contains(lower, "no space left on device") &&
+			!strings.Contains(lower, "not enough free disk space") &&
+			!strings.Contains(lower, "you don't have enough free space") {
+			return Diagnosis{}, false
+		}
+		return Diagnosis{
+			Kind:    FailureDiskFull,
+			Problem: i18n.T("diagnose.no_space"),
+			Remedy:  i18n.T("diagnose.no_space_fix"),
+			Fix: func() error {
+				return cleanPackageCache(manager)
+			},
+		}, true
+	}
+}
+
+// notFound reconoce los paquetes que no existen en los repositorios con re,
+// cuyo primer grupo es el nombre del paquete
+func notFound(re *regexp.Regexp) diagnoseRule {
+	return func(output string, lower string) (Diagnosis, bool) {
+		var missing []string
+		for _, match := range re.FindAllStringSubmatch(output, -1) {
+			missing = append(missing, match[1])
+		}
+		if len(missing) == 0 {
+			return Diagnosis{}, false
+		}
+		return Diagnosis{
+			Kind:    FailureNotFound,
+			Problem: i18n.T("diagnose.not_found", strings.Join(missing, ", ")),
+			Remedy:  i18n.T("diagnose.not_found_fix"),
+			Exclude: missing,
+		}, true
+	}
+}
+
+// offerFix pregunta si aplicar la corrección de un diagnóstico; es una variable
+// para que los tests no abran formularios
+var offerFix = Diagnosis.OfferFix
+
+// OfferFix muestra el diagnóstico y pregunta si aplicar la corrección.
+// Retorna true si se aplicó y conviene reintentar.
+func (d Diagnosis) OfferFix() bool {
+	fmt.Println(ui.Warning(d.Problem))
+
+	var apply bool
+	form := ui.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(i18n.T("diagnose.apply_fix")).
+				Description(d.Remedy).
+				Affirmative(i18n.T("diagnose.apply_fix_yes")).
+				Negative(i18n.T("common.no")).
+				Value(&apply),
+		),
+	)
+	if err := form.RunConfirm(&apply); err != nil || !apply {
+		return false
+	}
+
+	if d.Fix != nil {
+		if err := d.Fix(); err != nil {
+			fmt.Println(ui.Error(i18n.T("diagnose.fix_failed", err)))
+			return false
+		}
+	}
+	return true
+}
+
+// removeStalePacmanLock elimina db.lck solo si no hay otro pacman en ejecución
+func removeStalePacmanLock() error {
+	if _, err := utils.RunCommandSilent("pgrep", "-x", "pacman"); err == nil {
+		return errors.New(i18n.T("diagnose.pacman_running"))
+	}
+	if _, err := os.Stat(pacmanLockPath); os.IsNotExist(err) {
+		return nil
+	}
+	return utils.RunCommandWithSudo("rm", "-f", pacmanLockPath)
+}
+
+// waitForAptLock espera a que terminen los procesos de apt/dpkg
+func waitForAptLock() error {
+	fmt.Println(ui.Info(i18n.T("diagnose.waiting_apt")))
+	deadline := time.Now().Add(2 * time.Minute)
+	for time.Now().Before(deadline) {
+		busy := false
+		for _, proc := range []string{"apt", "apt-get", "dpkg", "unattended-upgr"} {
+			if _, err := utils.RunCommandSilent("pgrep", "-x", proc); err == nil {
+				busy = true
+				break
+			}
+		}
+		if !busy {
+			return nil
+		}
+		time.Sleep(5 * time.Second)
+	}
+	return errors.New(i18n.T("diagnose.apt_still_busy"))
+}
+
+// diagnoseHeld distingue entre paquetes retenidos con apt-mark y dependencias rotas
+func diagnoseHeld() Diagnosis {
+	held, _ := utils.RunCommandSilent("apt-mark", "showhold")
+	heldPkgs := splitLines(held)
+
+	if len(heldPkgs) > 0 {
+		return Diagnosis{
+			Kind:    FailureHeldPackages,
+			Problem: i18n.T("diagnose.held", strings.Join(heldPkgs, ", ")),
+			Remedy:  i18n.T("diagnose.held_fix"),
+			Fix: func() error {
+				args := append([]string{"unhold"}, heldPkgs...)
+				return utils.RunCommandWithSudo("apt-mark", args...)
+			},
+		}
+	}
+
+	return Diagnosis{
+		Kind:    FailureHeldPackages,
+		Problem: i18n.T("diagnose.broken"),
+		Remedy:  i18n.T("diagnose.broken_fix"),
+		Fix: func() error {
+			return utils.RunCommandWithSudo("apt", "--fix-broken", "install", "-y")
+		},
+	}
+}
+
+// cleanPackageCache libera espacio borrando paquetes descargados
+func cleanPackageCache(manager string) error {
+	switch manager {
+	case "pacman":
+		if utils.CommandExists("paccache") {
+			return utils.RunCommandWithSudo("paccache", "-rk1")
+		}
+		return utils.RunCommandWithSudo("pacman", "-Sc", "--noconfirm")
+	case "apt":
+		return utils.RunCommandWithSudo("apt", "clean")
+	case "dnf":
+		return utils.RunCommandWithSudo("dnf", "clean", "packages")
+	case "flatpak":
+		return utils.RunCommand("flatpak", "uninstall", "--unused", "-y")
+	default:
+		return fmt.Errorf(i18n.T("common.unsupported_manager"), manager)
+	}
+}
@@ -0,0 +1,113 @@
+package packages
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiagnose(t *testing.T) {
+	tests := []struct {
+		name     string
+		manager  string
+		output   string
+		wantKind string // "" si no se reconoce
+		wantArgs []string
+		wantExcl []string
+	}{
+		{
+			name:     "lock de pacman",
+			manager:  "pacman",
+			output:   "error: failed to init transaction (unable to lock database)\nerror: could not lock database: File exists\n  if you're sure a package manager is not already\n  running, you can remove /var/lib/pacman/db.lck",
+			wantKind: FailureDBLock,
+		},
+		{
+			name:     "firma inválida en pacman",
+			manager:  "pacman",
+			output:   "error: foo: signature from \"Someone <a@b>\" is unknown trust",
+			wantKind: FailureKeyring,
+		},
+		{
+			name:     "paru usa las reglas de pacman",
+			manager:  "paru",
+			output:   "error: target not found: nope",
+			wantKind: FailureNotFound,
+			wantExcl: []string{"nope"},
+		},
+		{
+			name:     "archivos en conflicto",
+			manager:  "pacman",
+			output:   "error: failed to commit transaction (conflicting files)\nfoo: /usr/bin/foo exists in filesystem\nfoo: /usr/lib/libfoo.so exists in filesystem",
+			wantKind: FailureFileConflict,
+			wantArgs: []string{"--overwrite", "/usr/bin/foo", "--overwrite", "/usr/lib/libfoo.so"},
+		},
+		{
+			name:    "una firma en apt no propone el llavero de Arch",
+			manager: "apt",
+			output:  "W: GPG error: http://deb.example.org stable InRelease: The following signatures couldn't be verified because the public key is not available: NO_PUBKEY 0123\nE: The repository is not signed. Invalid signature from the repository.",
+		},
+		{
+			name:     "lock de apt",
+			manager:  "apt",
+			output:   "E: Could not get lock /var/lib/dpkg/lock-frontend. It is held by process 1234 (unattended-upgr)",
+			wantKind: FailureDBLock,
+		},
+		{
+			name:     "dpkg interrumpido",
+			manager:  "apt",
+			output:   "E: dpkg was interrupted, you must manually run 'dpkg --configure -a' to correct the problem.",
+			wantKind: FailureDpkgInterrupted,
+		},
+		{
+			name:     "paquete inexistente en apt",
+			manager:  "apt",
+			output:   "E: Unable to locate package nope",
+			wantKind: FailureNotFound,
+			wantExcl: []string{"nope"},
+		},
+		{
+			name:    "el lock de pacman no se reconoce en dnf",
+			manager: "dnf",
+			output:  "could not lock database /var/lib/pacman/db.lck",
+		},
+		{
+			name:     "rpmdb dañada",
+			manager:  "dnf",
+			output:   "error: rpmdb: BDB0113 Thread/process 1234 failed\nerror: cannot open Packages database in /var/lib/rpm",
+			wantKind: FailureRpmdb,
+		},
+		{
+			name:     "paquete inexistente en dnf",
+			manager:  "dnf",
+			output:   "No match for argument: nope\nError: Unable to find a match: nope",
+			wantKind: FailureNotFound,
+			wantExcl: []string{"nope"},
+		},
+		{
+			name:     "disco lleno en flatpak",
+			manager:  "flatpak",
+			output:   "error: Failed to install: No space left on device",
+			wantKind: FailureDiskFull,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := Diagnose(tt.manager, tt.output)
+			if tt.wantKind == "" {
+				if ok {
+					t.Fatalf("se reconoció %q, se esperaba ningún diagnóstico", d.Kind)
+				}
+				return
+			}
+			if !ok || d.Kind != tt.wantKind {
+				t.Fatalf("diagnóstico %q (%v), se esperaba %q", d.Kind, ok, tt.wantKind)
+			}
+			if !reflect.DeepEqual(d.ExtraArgs, tt.wantArgs) {
+				t.Errorf("argumentos %v, se esperaba %v", d.ExtraArgs, tt.wantArgs)
+			}
+			if !reflect.DeepEqual(d.Exclude, tt.wantExcl) {
+				t.Errorf("excluidos %v, se esperaba %v", d.Exclude, tt.wantExcl)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package packages
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/utils"
+)
+
+// ListInfo describe un archivo .lst encontrado en el repositorio dotfiles
+type ListInfo struct {
+	Name        string // Nombre del comando, ej: "networks" para pkg_networks.lst
+	File        string // Nombre del archivo, ej: "pkg_networks.lst"
+	Title       string
+	Description string
+}
+
+// nonPackageLists son archivos .lst que no son listas de paquetes
+var nonPackageLists = map[string]bool{
+	"copr.lst": true,
+}
+
+// DiscoverLists busca todos los .lst de packages/<listDir>/ y lee su encabezado.
+// El encabezado es opcional y se escribe como comentarios al inicio del archivo:
+//
+//	# title: Herramientas de desarrollo
+//	# description: Compiladores, depuradores y LSPs
+func DiscoverLists(listDir string) ([]ListInfo, error) {
+	dir := filepath.Join(utils.GetDotfilesDir(), "packages", listDir)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.lst"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var lists []ListInfo
+	for _, path := range files {
+		file := filepath.Base(path)
+		// Los overlays personales se mezclan con su lista, no son listas propias
+		if nonPackageLists[file] || strings.HasSuffix(file, ".local.lst") {
+			continue
+		}
+
+		name := strings.TrimPrefix(strings.TrimSuffix(file, ".lst"), "pkg_")
+		info := ListInfo{
+			Name:  name,
+			File:  file,
+			Title: i18n.T("packages.list_title", name),
+		}
+
+		title, description := readListHeader(path)
+		if title != "" {
+			info.Title = title
+		}
+		info.Description = description
+
+		lists = append(lists, info)
+	}
+
+	return lists, nil
+}
+
+// readListHeader lee title y description de los comentarios iniciales de un .lst
+func readListHeader(path string) (title string, description string) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		// El encabezado termina en la primera línea que no es comentario o en la primera sección
+		if !strings.HasPrefix(line, "#") || strings.Contains(line, "===") {
+			break
+		}
+
+		comment := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		key, value, found := strings.Cut(comment, ":")
+		if !found {
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "title":
+			title = strings.TrimSpace(value)
+		case "description":
+			description = strings.TrimSpace(value)
+		}
+	}
+
+	return title, description
+}
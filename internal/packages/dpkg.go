@@ -0,0 +1,419 @@
+package packages
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	dpkgStatusPath = "/var/lib/dpkg/status"
+	aptListsDir    = "/var/lib/apt/lists"
+)
+
+// DebPackage representa una entrada de /var/lib/dpkg/status o de un índice Packages de apt
+type DebPackage struct {
+	Name          string
+	Version       string
+	Status        string // Solo en dpkg/status, ej: "install ok installed"
+	Architecture  string
+	Section       string
+	Provides      []string
+	Depends       []string // Nombres de Depends y Pre-Depends, incluyendo alternativas
+	Description   string   // Solo la primera línea (sinopsis)
+	InstalledSize int64    // En KiB
+	DownloadSize  int64    // Solo en índices de apt, en bytes
+	Origin        string   // Solo en índices de apt, ej: "bookworm/main"
+}
+
+// IsInstalled indica si dpkg considera el paquete realmente instalado: el tercer
+// campo de Status ("<deseado> <error> <estado>") es "installed", sin importar si
+// el paquete está retenido ("hold ok installed") o marcado para quitar.
+// Las entradas "rc" (solo archivos de configuración) no cuentan como instaladas.
+func (p DebPackage) IsInstalled() bool {
+	fields := strings.Fields(p.Status)
+	return len(fields) == 3 && fields[2] == "installed"
+}
+
+// AptCandidate representa la versión que apt instalaría para un nombre dado.
+// Es una aproximación: se toma la versión más alta de los índices, sin
+// considerar el pinning ni las prioridades de apt_preferences.
+type AptCandidate struct {
+	Name        string
+	Version     string
+	Description string
+	ProvidedBy  string // Si el nombre es virtual, paquete real que lo provee
+}
+
+// parseDebControl recorre un archivo en formato de control Debian (stanzas separadas
+// por líneas vacías) y llama a fn con los campos de cada stanza
+func parseDebControl(r io.Reader, fn func(fields map[string]string)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	fields := make(map[string]string)
+
+	flush := func() {
+		if len(fields) > 0 {
+			fn(fields)
+			fields = make(map[string]string)
+		}
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+
+		// Líneas de continuación (descripción larga, conffiles, etc.) se ignoran
+		if line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+
+		idx := strings.Index(line, ":")
+		if idx <= 0 {
+			continue
+		}
+
+		fields[line[:idx]] = strings.TrimSpace(line[idx+1:])
+	}
+	flush()
+
+	return scanner.Err()
+}
+
+// debPackageFromFields construye un DebPackage a partir de los campos de una stanza
+func debPackageFromFields(fields map[string]string) DebPackage {
+	pkg := DebPackage{
+		Name:         fields["Package"],
+		Version:      fields["Version"],
+		Status:       fields["Status"],
+		Architecture: fields["Architecture"],
+		Section:      fields["Section"],
+		Description:  fields["Description"],
+		Provides:     parseProvides(fields["Provides"]),
+		Depends:      parseDepends(fields["Pre-Depends"] + ", " + fields["Depends"]),
+	}
+
+	if size, err := strconv.ParseInt(fields["Installed-Size"], 10, 64); err == nil {
+		pkg.InstalledSize = size
+	}
+	if size, err := strconv.ParseInt(fields["Size"], 10, 64); err == nil {
+		pkg.DownloadSize = size
+	}
+
+	return pkg
+}
+
+// parseProvides extrae los nombres virtuales de un campo Provides,
+// ej: "awk, editor (= 1.0)" -> ["awk", "editor"]
+func parseProvides(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	var names []string
+	for _, part := range strings.Split(value, ",") {
+		name := strings.TrimSpace(part)
+		if idx := strings.IndexAny(name, " ("); idx > 0 {
+			name = name[:idx]
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parseDepends extrae los nombres de un campo Depends, incluyendo cada alternativa,
+// ej: "libc6 (>= 2.34), awk | mawk, python3:any" -> ["libc6", "awk", "mawk", "python3"]
+func parseDepends(value string) []string {
+	var names []string
+	for _, alternative := range parseProvides(strings.ReplaceAll(value, "|", ",")) {
+		name, _, _ := strings.Cut(alternative, ":")
+		names = append(names, name)
+	}
+	return names
+}
+
+// ReadDpkgStatus lee /var/lib/dpkg/status y retorna todas las entradas por nombre
+func ReadDpkgStatus() (map[string]DebPackage, error) {
+	return readDpkgStatusFile(dpkgStatusPath)
+}
+
+func readDpkgStatusFile(path string) (map[string]DebPackage, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := make(map[string]DebPackage)
+	err = parseDebControl(file, func(fields map[string]string) {
+		pkg := debPackageFromFields(fields)
+		if pkg.Name == "" {
+			return
+		}
+		// En sistemas multiarch puede haber varias entradas; preferir la instalada
+		if prev, ok := entries[pkg.Name]; ok && prev.IsInstalled() && !pkg.IsInstalled() {
+			return
+		}
+		entries[pkg.Name] = pkg
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// DpkgInstalledSet retorna el conjunto de nombres instalados, incluyendo los
+// nombres virtuales provistos por paquetes instalados
+func DpkgInstalledSet() (map[string]bool, error) {
+	entries, err := ReadDpkgStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	installed := make(map[string]bool)
+	for name, pkg := range entries {
+		if !pkg.IsInstalled() {
+			continue
+		}
+		installed[name] = true
+		for _, virtual := range pkg.Provides {
+			installed[virtual] = true
+		}
+	}
+
+	return installed, nil
+}
+
+// aptIndex agrupa los candidatos de todos los índices Packages habilitados
+type aptIndex struct {
+	packages  map[string]DebPackage // Mejor versión por nombre real
+	providers map[string]string     // Nombre virtual -> paquete real
+}
+
+var (
+	aptIndexOnce  sync.Once
+	aptIndexCache *aptIndex
+)
+
+// loadAptIndex lee una sola vez los índices de /var/lib/apt/lists
+func loadAptIndex() *aptIndex {
+	aptIndexOnce.Do(func() {
+		aptIndexCache = readAptIndex(aptListsDir)
+	})
+	return aptIndexCache
+}
+
+func readAptIndex(dir string) *aptIndex {
+	index := &aptIndex{
+		packages:  make(map[string]DebPackage),
+		providers: make(map[string]string),
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*_Packages"))
+	for _, path := range files {
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		origin := aptIndexOrigin(filepath.Base(path))
+
+		parseDebControl(file, func(fields map[string]string) {
+			pkg := debPackageFromFields(fields)
+			if pkg.Name == "" {
+				return
+			}
+			pkg.Origin = origin
+
+			// Se aproxima el candidato de apt con la versión más alta disponible
+			if prev, ok := index.packages[pkg.Name]; !ok || CompareDebVersions(pkg.Version, prev.Version) > 0 {
+				index.packages[pkg.Name] = pkg
+			}
+			for _, virtual := range pkg.Provides {
+				if _, ok := index.providers[virtual]; !ok {
+					index.providers[virtual] = pkg.Name
+				}
+			}
+		})
+		file.Close()
+	}
+
+	return index
+}
+
+// aptIndexOrigin obtiene "suite/componente" del nombre de un índice, ej:
+// deb.debian.org_debian_dists_bookworm_main_binary-amd64_Packages -> bookworm/main
+func aptIndexOrigin(filename string) string {
+	_, rest, found := strings.Cut(filename, "_dists_")
+	if !found {
+		return "apt"
+	}
+
+	parts := strings.Split(strings.TrimSuffix(rest, "_Packages"), "_")
+	if len(parts) >= 2 {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
+
+// LookupAptPackage busca un paquete (o el proveedor de un nombre virtual) en los índices de apt
+func LookupAptPackage(name string) (DebPackage, bool) {
+	index := loadAptIndex()
+	if pkg, ok := index.packages[name]; ok {
+		return pkg, true
+	}
+	if provider, ok := index.providers[name]; ok {
+		pkg, ok := index.packages[provider]
+		return pkg, ok
+	}
+	return DebPackage{}, false
+}
+
+// AptIndexAvailable indica si hay índices de apt descargados para consultar
+func AptIndexAvailable() bool {
+	return len(loadAptIndex().packages) > 0
+}
+
+// ResolveAptCandidates busca en los índices de apt la versión candidata de cada
+// paquete (la más alta disponible; ignora el pinning de apt).
+// Retorna los candidatos encontrados y los nombres que no existen en ningún repositorio habilitado.
+func ResolveAptCandidates(packages []string) (map[string]AptCandidate, []string) {
+	index := loadAptIndex()
+	candidates := make(map[string]AptCandidate)
+	var missing []string
+
+	for _, name := range packages {
+		if pkg, ok := index.packages[name]; ok {
+			candidates[name] = AptCandidate{
+				Name:        name,
+				Version:     pkg.Version,
+				Description: pkg.Description,
+			}
+			continue
+		}
+
+		if provider, ok := index.providers[name]; ok {
+			pkg := index.packages[provider]
+			candidates[name] = AptCandidate{
+				Name:        name,
+				Version:     pkg.Version,
+				Description: pkg.Description,
+				ProvidedBy:  provider,
+			}
+			continue
+		}
+
+		missing = append(missing, name)
+	}
+
+	return candidates, missing
+}
+
+// CompareDebVersions compara dos versiones Debian ([epoch:]upstream[-revision]).
+// Retorna -1, 0 o 1 según a sea menor, igual o mayor que b.
+func CompareDebVersions(a, b string) int {
+	epochA, upA, revA := splitDebVersion(a)
+	epochB, upB, revB := splitDebVersion(b)
+
+	if epochA != epochB {
+		if epochA < epochB {
+			return -1
+		}
+		return 1
+	}
+
+	if c := compareDebFragment(upA, upB); c != 0 {
+		return c
+	}
+	return compareDebFragment(revA, revB)
+}
+
+func splitDebVersion(version string) (epoch int, upstream string, revision string) {
+	if idx := strings.Index(version, ":"); idx > 0 {
+		epoch, _ = strconv.Atoi(version[:idx])
+		version = version[idx+1:]
+	}
+
+	upstream = version
+	if idx := strings.LastIndex(version, "-"); idx >= 0 {
+		upstream = version[:idx]
+		revision = version[idx+1:]
+	}
+
+	return epoch, upstream, revision
+}
+
+// debCharOrder implementa el orden de dpkg: '~' antes que todo, luego el fin de
+// cadena, luego letras y finalmente el resto de símbolos
+func debCharOrder(c byte) int {
+	switch {
+	case c == '~':
+		return -1
+	case unicode.IsLetter(rune(c)):
+		return int(c)
+	default:
+		return int(c) + 256
+	}
+}
+
+func compareDebFragment(a, b string) int {
+	for a != "" || b != "" {
+		// Parte no numérica
+		for (a != "" && !isDigit(a[0])) || (b != "" && !isDigit(b[0])) {
+			var ca, cb int
+			if a != "" && !isDigit(a[0]) {
+				ca = debCharOrder(a[0])
+			}
+			if b != "" && !isDigit(b[0]) {
+				cb = debCharOrder(b[0])
+			}
+			if ca != cb {
+				if ca < cb {
+					return -1
+				}
+				return 1
+			}
+			if a != "" && !isDigit(a[0]) {
+				a = a[1:]
+			}
+			if b != "" && !isDigit(b[0]) {
+				b = b[1:]
+			}
+		}
+
+		// Parte numérica
+		var na, nb int
+		for a != "" && isDigit(a[0]) {
+			na = na*10 + int(a[0]-'0')
+			a = a[1:]
+		}
+		for b != "" && isDigit(b[0]) {
+			nb = nb*10 + int(b[0]-'0')
+			b = b[1:]
+		}
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
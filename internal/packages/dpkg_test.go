@@ -0,0 +1,34 @@
+package packages
+
+import "testing"
+
+func TestCompareDebVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0", "1.0", 0},
+		{"1:1.0", "2.0", 1},
+		{"0:2.0", "2.0", 0},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0~rc1", "1.0~rc2", -1},
+		{"1.0~~", "1.0~", -1},
+		{"1.0-1", "1.0-2", -1},
+		{"1.0-10", "1.0-9", 1},
+		{"1.0", "1.0-0", 0},
+		{"1.10", "1.9", 1},
+		{"1.0a", "1.0", 1},
+		{"1.0a", "1.0+", -1},
+		{"2.30-0ubuntu1", "2.30-0ubuntu1.1", -1},
+		{"1.2-3-4", "1.2-3-5", -1},
+	}
+
+	for _, tt := range tests {
+		if got := CompareDebVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareDebVersions(%q, %q) = %d, se esperaba %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := CompareDebVersions(tt.b, tt.a); got != -tt.want {
+			t.Errorf("CompareDebVersions(%q, %q) = %d, se esperaba %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package packages
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/utils"
+)
+
+// ExplicitPackages obtiene los paquetes instalados explícitamente por el usuario
+// (no como dependencia), agrupados por repositorio o sección.
+// manager puede ser "pacman", "apt", "dnf" o "flatpak".
+func ExplicitPackages(manager string) ([]PackageGroup, error) {
+	var byGroup map[string][]string
+	var err error
+
+	switch manager {
+	case "pacman":
+		byGroup, err = explicitPacman()
+	case "apt":
+		byGroup, err = explicitApt()
+	case "dnf":
+		byGroup, err = explicitDnf()
+	case "flatpak":
+		byGroup, err = explicitFlatpak()
+	default:
+		return nil, fmt.Errorf(i18n.T("common.unsupported_manager"), manager)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return sortedGroups(byGroup), nil
+}
+
+// explicitPacman agrupa los paquetes explícitos por repositorio; los que no
+// pertenecen a ningún repo (pacman -Qm) se agrupan como AUR
+func explicitPacman() (map[string][]string, error) {
+	output, err := utils.RunCommandSilent("pacman", "-Qqe")
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("packages.query_pacman_failed"), err)
+	}
+
+	// pacman -Sl: "<repo> <paquete> <versión> [installed]"
+	repoOf := make(map[string]string)
+	if repos, err := utils.RunCommandSilent("pacman", "-Sl"); err == nil {
+		for _, line := range strings.Split(repos, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) >= 2 {
+				if _, exists := repoOf[fields[1]]; !exists {
+					repoOf[fields[1]] = fields[0]
+				}
+			}
+		}
+	}
+
+	byGroup := make(map[string][]string)
+	for _, pkg := range splitLines(output) {
+		repo := repoOf[pkg]
+		if repo == "" {
+			repo = "aur"
+		}
+		byGroup[repo] = append(byGroup[repo], pkg)
+	}
+	return byGroup, nil
+}
+
+// explicitApt agrupa los paquetes marcados como manuales por su sección de Debian
+func explicitApt() (map[string][]string, error) {
+	output, err := utils.RunCommandSilent("apt-mark", "showmanual")
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("packages.query_aptmark_failed"), err)
+	}
+
+	status, _ := ReadDpkgStatus()
+
+	byGroup := make(map[string][]string)
+	for _, pkg := range splitLines(output) {
+		section := "misc"
+		if deb, ok := status[pkg]; ok && deb.Section != "" {
+			// "contrib/utils" -> "utils"
+			section = deb.Section[strings.LastIndex(deb.Section, "/")+1:]
+		}
+		byGroup[section] = append(byGroup[section], pkg)
+	}
+	return byGroup, nil
+}
+
+// explicitDnf agrupa los paquetes instalados por el usuario según el repo de origen
+func explicitDnf() (map[string][]string, error) {
+	output, err := utils.RunCommandSilent("dnf", "repoquery", "--userinstalled", "--qf", "%{name} %{from_repo}\n")
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("packages.query_dnf_failed"), err)
+	}
+
+	byGroup := make(map[string][]string)
+	for _, line := range splitLines(output) {
+		fields := strings.Fields(line)
+		repo := "misc"
+		if len(fields) >= 2 {
+			repo = fields[1]
+		}
+		byGroup[repo] = append(byGroup[repo], fields[0])
+	}
+	return byGroup, nil
+}
+
+// explicitFlatpak agrupa las aplicaciones Flatpak por remoto
+func explicitFlatpak() (map[string][]string, error) {
+	output, err := utils.RunCommandSilent("flatpak", "list", "--app", "--columns=application,origin")
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("packages.query_flatpak_failed"), err)
+	}
+
+	byGroup := make(map[string][]string)
+	for _, line := range splitLines(output) {
+		fields := strings.Fields(line)
+		origin := "flathub"
+		if len(fields) >= 2 {
+			origin = fields[1]
+		}
+		byGroup[origin] = append(byGroup[origin], fields[0])
+	}
+	return byGroup, nil
+}
+
+// splitLines separa la salida de un comando en líneas no vacías
+func splitLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// sortedGroups convierte un mapa grupo -> paquetes en grupos ordenados por nombre
+func sortedGroups(byGroup map[string][]string) []PackageGroup {
+	var names []string
+	for name := range byGroup {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var groups []PackageGroup
+	for _, name := range names {
+		pkgs := append([]string(nil), byGroup[name]...)
+		sort.Strings(pkgs)
+		groups = append(groups, PackageGroup{Name: name, Packages: pkgs})
+	}
+	return groups
+}
+
+// SubtractPackages quita de los grupos los paquetes ya cubiertos; descarta los grupos vacíos
+func SubtractPackages(groups []PackageGroup, covered map[string]bool) []PackageGroup {
+	var result []PackageGroup
+	for _, group := range groups {
+		var pkgs []string
+		for _, pkg := range group.Packages {
+			if !covered[pkg] {
+				pkgs = append(pkgs, pkg)
+			}
+		}
+		if len(pkgs) > 0 {
+			result = append(result, PackageGroup{Name: group.Name, Packages: pkgs})
+		}
+	}
+	return result
+}
@@ -0,0 +1,258 @@
+package packages
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"orgmos/internal/utils"
+)
+
+// infoWorkers es la cantidad de consultas simultáneas al gestor de paquetes
+const infoWorkers = 8
+
+// PackageInfo contiene los datos que se muestran junto a cada paquete en el picker
+type PackageInfo struct {
+	Description   string
+	Source        string // Repositorio: "extra", "aur", "bookworm/main", "flathub", ...
+	InstalledSize string // Tamaño instalado legible, ej: "12.3 MiB"
+}
+
+// FetchPackageInfo consulta en paralelo la descripción, origen y tamaño de cada paquete.
+// manager puede ser "pacman", "apt", "dnf" o "flatpak".
+func FetchPackageInfo(manager string, packages []string) map[string]PackageInfo {
+	result := make(map[string]PackageInfo)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	jobs := make(chan string)
+	for i := 0; i < infoWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pkg := range jobs {
+				info := fetchOne(manager, pkg)
+				mu.Lock()
+				result[pkg] = info
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, pkg := range packages {
+		jobs <- pkg
+	}
+	close(jobs)
+	wg.Wait()
+
+	return result
+}
+
+func fetchOne(manager string, pkg string) PackageInfo {
+	switch manager {
+	case "pacman":
+		return fetchPacmanInfo(pkg)
+	case "apt":
+		return fetchAptInfo(pkg)
+	case "dnf":
+		return fetchDnfInfo(pkg)
+	case "flatpak":
+		return fetchFlatpakInfo(pkg)
+	default:
+		return PackageInfo{}
+	}
+}
+
+// untranslatedEnv hace que pacman, dnf y flatpak muestren sus campos en inglés:
+// con otro idioma "Description" pasa a ser, por ejemplo, "Descripción"
+var untranslatedEnv = []string{"LC_ALL=C.UTF-8"}
+
+// runInfoQuery ejecuta una consulta cuya salida se lee con parseInfoFields
+func runInfoQuery(name string, args ...string) (string, error) {
+	return utils.RunCommandSilentEnv(untranslatedEnv, name, args...)
+}
+
+// parseInfoFields convierte la salida "Clave : valor" de pacman/dnf/flatpak en un mapa.
+// Las líneas con sangría y sin clave continúan el valor anterior: pacman parte así
+// las listas largas, como "Required By".
+func parseInfoFields(output string) map[string]string {
+	fields := make(map[string]string)
+	last := ""
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			if continued := strings.TrimSpace(line); last != "" && continued != "" && line[0] == ' ' {
+				fields[last] += " " + continued
+			}
+			continue
+		}
+		key = strings.TrimSpace(key)
+		last = ""
+		if _, exists := fields[key]; !exists && key != "" {
+			fields[key] = strings.TrimSpace(value)
+			last = key
+		}
+	}
+	return fields
+}
+
+func fetchPacmanInfo(pkg string) PackageInfo {
+	output, err := runInfoQuery("pacman", "-Si", pkg)
+	if err == nil {
+		fields := parseInfoFields(output)
+		return PackageInfo{
+			Description:   fields["Description"],
+			Source:        fields["Repository"],
+			InstalledSize: fields["Installed Size"],
+		}
+	}
+
+	// Buscar en AUR con el helper disponible
+	for _, helper := range []string{"paru", "yay"} {
+		if !utils.CommandExists(helper) {
+			continue
+		}
+		output, err := runInfoQuery(helper, "-Si", pkg)
+		if err == nil {
+			return PackageInfo{
+				Description: parseInfoFields(output)["Description"],
+				Source:      "aur",
+			}
+		}
+		break
+	}
+
+	return PackageInfo{Source: "unknown"}
+}
+
+func fetchAptInfo(pkg string) PackageInfo {
+	deb, ok := LookupAptPackage(pkg)
+	if !ok {
+		return PackageInfo{Description: GetAptPackageDescription(pkg), Source: "apt"}
+	}
+
+	info := PackageInfo{
+		Description: deb.Description,
+		Source:      deb.Origin,
+	}
+	if deb.InstalledSize > 0 {
+		info.InstalledSize = FormatSize(deb.InstalledSize * 1024)
+	}
+	return info
+}
+
+func fetchDnfInfo(pkg string) PackageInfo {
+	output, err := runInfoQuery("dnf", "info", "--quiet", pkg)
+	if err != nil {
+		return PackageInfo{Source: "unknown"}
+	}
+
+	fields := parseInfoFields(output)
+	source := fields["Repository"]
+	if source == "" {
+		source = fields["From repo"]
+	}
+	return PackageInfo{
+		Description:   fields["Summary"],
+		Source:        source,
+		InstalledSize: fields["Size"],
+	}
+}
+
+func fetchFlatpakInfo(appID string) PackageInfo {
+	_, description := GetFlatpakInfo(appID)
+	info := PackageInfo{Description: description, Source: "flathub"}
+
+	if output, err := runInfoQuery("flatpak", "remote-info", "flathub", appID); err == nil {
+		info.InstalledSize = parseInfoFields(output)["Installed"]
+	}
+	return info
+}
+
+// FormatSize convierte bytes en un tamaño legible (KiB, MiB, GiB)
+func FormatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	value := float64(bytes) / unit
+	for _, suffix := range []string{"KiB", "MiB", "GiB"} {
+		if value < unit || suffix == "GiB" {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f GiB", value)
+}
+
+// EstimateSizes calcula el tamaño total de descarga e instalación de los paquetes
+// en bytes. unknown es cuántos paquetes quedaron fuera por no tener información
+// (AUR, dnf, flatpak o una consulta fallida): la estimación es un mínimo.
+func EstimateSizes(manager string, packages []string) (download int64, installed int64, unknown int) {
+	if len(packages) == 0 {
+		return 0, 0, 0
+	}
+
+	switch manager {
+	case "pacman":
+		args := append([]string{"-Si"}, packages...)
+		// pacman -Si falla si algún paquete no existe, pero igual imprime el resto:
+		// los que faltan se cuentan como desconocidos
+		output, _ := runInfoQuery("pacman", args...)
+		found := 0
+		for _, block := range strings.Split(output, "\n\n") {
+			fields := parseInfoFields(block)
+			if fields["Name"] == "" {
+				continue
+			}
+			found++
+			download += ParseSize(fields["Download Size"])
+			installed += ParseSize(fields["Installed Size"])
+		}
+		unknown = max(len(packages)-found, 0)
+	case "apt":
+		for _, pkg := range packages {
+			deb, ok := LookupAptPackage(pkg)
+			if !ok {
+				unknown++
+				continue
+			}
+			download += deb.DownloadSize
+			installed += deb.InstalledSize * 1024
+		}
+	default:
+		unknown = len(packages)
+	}
+
+	return download, installed, unknown
+}
+
+// ParseSize convierte un tamaño como "12.50 MiB" en bytes. Retorna 0 si no lo entiende.
+func ParseSize(value string) int64 {
+	fields := strings.Fields(strings.ReplaceAll(value, ",", "."))
+	if len(fields) != 2 {
+		return 0
+	}
+
+	number, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0
+	}
+
+	multipliers := map[string]float64{
+		"B":   1,
+		"KiB": 1 << 10,
+		"MiB": 1 << 20,
+		"GiB": 1 << 30,
+		"kB":  1e3,
+		"MB":  1e6,
+		"GB":  1e9,
+	}
+	multiplier, ok := multipliers[fields[1]]
+	if !ok {
+		return 0
+	}
+	return int64(number * multiplier)
+}
@@ -0,0 +1,29 @@
+package packages
+
+import "testing"
+
+func TestParseInfoFields(t *testing.T) {
+	output := `Name            : glibc
+Description     : GNU C Library
+Required By     : bash  coreutils  curl
+                  gcc-libs  zlib
+Optional Deps   : perl: for mtrace
+Optional For    : None
+Install Date    : Tue Jan 14 10:23:45 2025
+`
+	fields := parseInfoFields(output)
+
+	tests := map[string]string{
+		"Name":          "glibc",
+		"Description":   "GNU C Library",
+		"Required By":   "bash  coreutils  curl gcc-libs  zlib",
+		"Optional Deps": "perl: for mtrace",
+		"Optional For":  "None",
+		"Install Date":  "Tue Jan 14 10:23:45 2025",
+	}
+	for key, want := range tests {
+		if got := fields[key]; got != want {
+			t.Errorf("%s: %q, se esperaba %q", key, got, want)
+		}
+	}
+}
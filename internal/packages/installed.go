@@ -0,0 +1,133 @@
+package packages
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"orgmos/internal/utils"
+)
+
+// InstalledDetail contiene el tamaño y la fecha de instalación de un paquete instalado
+type InstalledDetail struct {
+	Size        string    // Tamaño legible, ej: "12.3 MiB"
+	InstallDate time.Time // Cero si el gestor no la expone
+}
+
+// InstalledDetails obtiene tamaño y fecha de instalación de paquetes ya instalados.
+// manager puede ser "pacman", "apt", "dnf" o "flatpak".
+func InstalledDetails(manager string, packages []string) map[string]InstalledDetail {
+	if len(packages) == 0 {
+		return make(map[string]InstalledDetail)
+	}
+
+	switch manager {
+	case "pacman":
+		return installedDetailsPacman(packages)
+	case "apt":
+		return installedDetailsApt(packages)
+	case "dnf":
+		return installedDetailsRpm(packages)
+	case "flatpak":
+		return installedDetailsFlatpak(packages)
+	default:
+		return make(map[string]InstalledDetail)
+	}
+}
+
+// pacmanDateLayout es el formato de "Install Date" de pacman -Qi en el locale C
+const pacmanDateLayout = time.ANSIC
+
+func installedDetailsPacman(packages []string) map[string]InstalledDetail {
+	details := make(map[string]InstalledDetail)
+
+	args := append([]string{"-Qi"}, packages...)
+	output, _ := runInfoQuery("pacman", args...)
+
+	// pacman -Qi separa cada paquete con una línea en blanco
+	for _, block := range strings.Split(output, "\n\n") {
+		fields := parseInfoFields(block)
+		name := fields["Name"]
+		if name == "" {
+			continue
+		}
+		detail := InstalledDetail{Size: fields["Installed Size"]}
+		// pacman muestra la hora local, ej: "Tue Jan 14 10:23:45 2025"
+		if date, err := time.ParseInLocation(pacmanDateLayout, fields["Install Date"], time.Local); err == nil {
+			detail.InstallDate = date
+		}
+		details[name] = detail
+	}
+	return details
+}
+
+func installedDetailsApt(packages []string) map[string]InstalledDetail {
+	details := make(map[string]InstalledDetail)
+	status, _ := ReadDpkgStatus()
+
+	for _, pkg := range packages {
+		var detail InstalledDetail
+		if deb, ok := status[pkg]; ok && deb.InstalledSize > 0 {
+			detail.Size = FormatSize(deb.InstalledSize * 1024)
+		}
+
+		// dpkg no guarda la fecha; se usa la del registro de archivos del paquete
+		matches, _ := filepath.Glob(filepath.Join("/var/lib/dpkg/info", pkg+"*.list"))
+		for _, match := range matches {
+			base := strings.TrimSuffix(filepath.Base(match), ".list")
+			if base != pkg && !strings.HasPrefix(base, pkg+":") {
+				continue
+			}
+			if info, err := os.Stat(match); err == nil {
+				detail.InstallDate = info.ModTime()
+			}
+			break
+		}
+
+		details[pkg] = detail
+	}
+	return details
+}
+
+func installedDetailsRpm(packages []string) map[string]InstalledDetail {
+	details := make(map[string]InstalledDetail)
+
+	args := append([]string{"-q", "--qf", "%{NAME}|%{SIZE}|%{INSTALLTIME}\n"}, packages...)
+	output, _ := utils.RunCommandSilent("rpm", args...)
+
+	for _, line := range splitLines(output) {
+		fields := strings.Split(line, "|")
+		if len(fields) != 3 {
+			continue
+		}
+		var detail InstalledDetail
+		if size, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+			detail.Size = FormatSize(size)
+		}
+		if stamp, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			detail.InstallDate = time.Unix(stamp, 0)
+		}
+		details[fields[0]] = detail
+	}
+	return details
+}
+
+func installedDetailsFlatpak(packages []string) map[string]InstalledDetail {
+	details := make(map[string]InstalledDetail)
+
+	output, _ := utils.RunCommandSilent("flatpak", "list", "--app", "--columns=application,size")
+	wanted := make(map[string]bool)
+	for _, pkg := range packages {
+		wanted[pkg] = true
+	}
+
+	for _, line := range splitLines(output) {
+		app, size, _ := strings.Cut(line, "\t")
+		if wanted[app] {
+			details[app] = InstalledDetail{Size: strings.TrimSpace(size)}
+		}
+	}
+	return details
+}
@@ -2,7 +2,9 @@ package packages
 
 import (
 	"fmt"
+	"io"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/ui"
 	"orgmos/internal/utils"
 )
@@ -13,10 +15,14 @@ func InstallPacman(packages []string) error {
 		return nil
 	}
 
-	fmt.Println(ui.Info(fmt.Sprintf("Instalando %d paquetes con pacman...", len(packages))))
+	fmt.Println(ui.Info(i18n.T("packages.installing_pacman", len(packages))))
 
 	args := append([]string{"-S", "--noconfirm", "--needed"}, packages...)
-	return utils.RunCommandWithSudo("pacman", args...)
+	_, err := runWithProgress("pacman", progressTitle("pacman", packages), func(out io.Writer, _ []string) (string, error) {
+		name, cmdArgs := trackedCommand(out, "pacman", args)
+		return utils.RunCommandWithSudoOutput(out, name, cmdArgs...)
+	})
+	return err
 }
 
 // InstallParu instala paquetes con paru (AUR)
@@ -26,14 +32,18 @@ func InstallParu(packages []string) error {
 	}
 
 	if !utils.CommandExists("paru") {
-		fmt.Println(ui.Warning("paru no está instalado. Instálalo primero para paquetes AUR."))
+		fmt.Println(ui.Warning(i18n.T("packages.paru_required")))
 		return nil
 	}
 
-	fmt.Println(ui.Info(fmt.Sprintf("Instalando %d paquetes AUR con paru...", len(packages))))
+	fmt.Println(ui.Info(i18n.T("packages.installing_paru", len(packages))))
 
 	args := append([]string{"-S", "--noconfirm", "--needed"}, packages...)
-	return utils.RunCommand("paru", args...)
+	_, err := runWithProgress("paru", progressTitle("paru", packages), func(out io.Writer, _ []string) (string, error) {
+		name, cmdArgs := trackedCommand(out, "paru", args)
+		return utils.RunUserCommandOutput(out, name, cmdArgs...)
+	})
+	return err
 }
 
 // InstallYay instala paquetes con yay (AUR)
@@ -43,14 +53,18 @@ func InstallYay(packages []string) error {
 	}
 
 	if !utils.CommandExists("yay") {
-		fmt.Println(ui.Warning("yay no está instalado. Instálalo primero para paquetes AUR."))
+		fmt.Println(ui.Warning(i18n.T("packages.yay_required")))
 		return nil
 	}
 
-	fmt.Println(ui.Info(fmt.Sprintf("Instalando %d paquetes AUR con yay...", len(packages))))
+	fmt.Println(ui.Info(i18n.T("packages.installing_yay", len(packages))))
 
 	args := append([]string{"-S", "--noconfirm", "--needed"}, packages...)
-	return utils.RunCommand("yay", args...)
+	_, err := runWithProgress("yay", progressTitle("yay", packages), func(out io.Writer, _ []string) (string, error) {
+		name, cmdArgs := trackedCommand(out, "yay", args)
+		return utils.RunUserCommandOutput(out, name, cmdArgs...)
+	})
+	return err
 }
 
 // InstallFlatpak instala aplicaciones Flatpak
@@ -60,14 +74,20 @@ func InstallFlatpak(packages []string) error {
 	}
 
 	if !utils.CommandExists("flatpak") {
-		fmt.Println(ui.Warning("flatpak no está instalado"))
+		fmt.Println(ui.Warning(i18n.T("packages.flatpak_missing")))
 		return nil
 	}
 
-	fmt.Println(ui.Info(fmt.Sprintf("Instalando %d aplicaciones Flatpak...", len(packages))))
+	fmt.Println(ui.Info(i18n.T("packages.installing_flatpak", len(packages))))
 
-	args := append([]string{"install", "-y", "flathub"}, packages...)
-	return utils.RunCommand("flatpak", args...)
+	return installIsolated("flatpak", packages, func(pkgs []string, extraArgs []string) (string, error) {
+		args := append(append([]string{"install", "-y"}, extraArgs...), "flathub")
+		args = append(args, pkgs...)
+		return runWithProgress("flatpak", progressTitle("flatpak", pkgs), func(out io.Writer, _ []string) (string, error) {
+			name, cmdArgs := trackedCommand(out, "flatpak", args)
+			return utils.RunCommandOutput(out, name, cmdArgs...)
+		})
+	})
 }
 
 // InstallApt instala paquetes con apt (Debian/Ubuntu)
@@ -76,15 +96,53 @@ func InstallApt(packages []string) error {
 		return nil
 	}
 
-	fmt.Println(ui.Info(fmt.Sprintf("Instalando %d paquetes con apt...", len(packages))))
+	fmt.Println(ui.Info(i18n.T("packages.installing_apt", len(packages))))
 
 	// Primero actualizar
 	if err := utils.RunCommandWithSudo("apt", "update"); err != nil {
-		return fmt.Errorf("error actualizando lista de paquetes: %w", err)
+		return fmt.Errorf(i18n.T("packages.apt_update_failed"), err)
 	}
 
-	args := append([]string{"install", "-y"}, packages...)
-	return utils.RunCommandWithSudo("apt", args...)
+	return installIsolated("apt", packages, func(pkgs []string, extraArgs []string) (string, error) {
+		return runWithProgress("apt", progressTitle("apt", pkgs), func(out io.Writer, progressArgs []string) (string, error) {
+			args := append(append(append([]string{"install", "-y"}, progressArgs...), extraArgs...), pkgs...)
+			name, cmdArgs := trackedCommand(out, "apt", args)
+			return utils.RunCommandWithSudoOutput(out, name, cmdArgs...)
+		})
+	})
+}
+
+// InstallDnf instala paquetes con dnf (Fedora)
+func InstallDnf(packages []string) error {
+	if len(packages) == 0 {
+		return nil
+	}
+
+	fmt.Println(ui.Info(i18n.T("packages.installing_dnf", len(packages))))
+
+	return installIsolated("dnf", packages, func(pkgs []string, extraArgs []string) (string, error) {
+		args := append(append([]string{"install", "-y"}, extraArgs...), pkgs...)
+		return runWithProgress("dnf", progressTitle("dnf", pkgs), func(out io.Writer, _ []string) (string, error) {
+			name, cmdArgs := trackedCommand(out, "dnf", args)
+			return utils.RunCommandWithSudoOutput(out, name, cmdArgs...)
+		})
+	})
+}
+
+// EnableCopr habilita repositorios COPR (owner/project) que aún no estén activos
+func EnableCopr(repos []string) error {
+	for _, repo := range repos {
+		if CheckCoprEnabled(repo) {
+			continue
+		}
+
+		fmt.Println(ui.Info(i18n.T("packages.enabling_copr", repo)))
+		if err := utils.RunCommandWithSudo("dnf", "copr", "enable", "-y", repo); err != nil {
+			return fmt.Errorf(i18n.T("packages.copr_failed"), repo, err)
+		}
+	}
+
+	return nil
 }
 
 // InstallCategorized instala paquetes separados por categoría
@@ -98,17 +156,17 @@ func InstallCategorized(categories map[string][]string) error {
 
 	// Multilib
 	if len(categories["multilib"]) > 0 {
-		fmt.Println(ui.Warning("Se requiere multilib habilitado para algunos paquetes"))
+		fmt.Println(ui.Warning(i18n.T("packages.multilib_required")))
 		if err := InstallPacman(categories["multilib"]); err != nil {
-			fmt.Println(ui.Warning(fmt.Sprintf("Error instalando multilib: %v", err)))
+			fmt.Println(ui.Warning(i18n.T("packages.multilib_failed", err)))
 		}
 	}
 
 	// Chaotic-AUR
 	if len(categories["chaotic"]) > 0 {
-		fmt.Println(ui.Warning("Se requiere chaotic-aur para algunos paquetes"))
+		fmt.Println(ui.Warning(i18n.T("packages.chaotic_required")))
 		if err := InstallPacman(categories["chaotic"]); err != nil {
-			fmt.Println(ui.Warning(fmt.Sprintf("Error instalando chaotic: %v", err)))
+			fmt.Println(ui.Warning(i18n.T("packages.chaotic_failed", err)))
 		}
 	}
 
@@ -126,31 +184,72 @@ func InstallCategorized(categories map[string][]string) error {
 // installer puede ser "pacman", "paru" o "yay"
 // Si es paru o yay, instala todos los paquetes juntos (pueden manejar repos oficiales y AUR)
 // Si es pacman, solo instala repos oficiales
+// Si la corrida falla, se aíslan los paquetes problemáticos y se instala el resto
 func InstallAllPackages(installer string, packages []string) error {
 	if len(packages) == 0 {
 		return nil
 	}
 
-	fmt.Println(ui.Info(fmt.Sprintf("Instalando %d paquetes en una sola corrida con %s...", len(packages), installer)))
-
-	// Si el instalador es paru o yay, puede instalar todo junto (repos oficiales + AUR)
-	if installer == "paru" {
-		if !utils.CommandExists("paru") {
-			return fmt.Errorf("paru no está instalado")
+	var install batchInstaller
+	switch installer {
+	case "paru", "yay":
+		// Pueden instalar todo junto (repos oficiales + AUR)
+		if !utils.CommandExists(installer) {
+			return fmt.Errorf(i18n.T("common.not_installed"), installer)
 		}
-		args := append([]string{"-S", "--noconfirm", "--needed"}, packages...)
-		return utils.RunCommand("paru", args...)
-	} else if installer == "yay" {
-		if !utils.CommandExists("yay") {
-			return fmt.Errorf("yay no está instalado")
+		install = func(pkgs []string, extraArgs []string) (string, error) {
+			args := append([]string{"-S", "--noconfirm", "--needed"}, extraArgs...)
+			if tool := utils.PrivilegeTool(); tool != "sudo" && tool != "" {
+				// paru y yay usan sudo por defecto para la parte de pacman
+				args = append(args, "--sudo", tool)
+			}
+			args = append(args, pkgs...)
+			return runWithProgress(installer, progressTitle(installer, pkgs), func(out io.Writer, _ []string) (string, error) {
+				name, cmdArgs := trackedCommand(out, installer, args)
+				return utils.RunUserCommandOutput(out, name, cmdArgs...)
+			})
 		}
-		args := append([]string{"-S", "--noconfirm", "--needed"}, packages...)
-		return utils.RunCommand("yay", args...)
-	} else if installer == "pacman" {
+	case "pacman":
 		// Pacman solo puede instalar repos oficiales
-		args := append([]string{"-S", "--noconfirm", "--needed"}, packages...)
-		return utils.RunCommandWithSudo("pacman", args...)
+		install = func(pkgs []string, extraArgs []string) (string, error) {
+			args := append(append([]string{"-S", "--noconfirm", "--needed"}, extraArgs...), pkgs...)
+			return runWithProgress("pacman", progressTitle("pacman", pkgs), func(out io.Writer, _ []string) (string, error) {
+				name, cmdArgs := trackedCommand(out, "pacman", args)
+				return utils.RunCommandWithSudoOutput(out, name, cmdArgs...)
+			})
+		}
+	default:
+		return fmt.Errorf(i18n.T("packages.unknown_installer"), installer)
+	}
+
+	fmt.Println(ui.Info(i18n.T("packages.installing_batch", len(packages), installer)))
+	return installIsolated("pacman", packages, install)
+}
+
+// InstallAlternates instala las herramientas que en esta distro vienen de otra fuente
+// (Flatpak o script de instalación)
+func InstallAlternates(alternates []Resolution) error {
+	var flatpakIDs []string
+	var scripts []Resolution
+	for _, res := range alternates {
+		switch res.Source {
+		case SourceFlatpak:
+			flatpakIDs = append(flatpakIDs, res.Package)
+		case SourceScript:
+			scripts = append(scripts, res)
+		}
+	}
+
+	if err := InstallFlatpak(flatpakIDs); err != nil {
+		return err
 	}
 
-	return fmt.Errorf("instalador desconocido: %s", installer)
+	for _, res := range scripts {
+		fmt.Println(ui.Info(i18n.T("packages.installing_script", res.Tool)))
+		if err := utils.RunCommand("bash", "-c", res.Package); err != nil {
+			return fmt.Errorf(i18n.T("packages.install_error"), res.Tool, err)
+		}
+	}
+
+	return nil
 }
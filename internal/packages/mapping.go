@@ -0,0 +1,219 @@
+package packages
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/utils"
+)
+
+// Fuentes posibles para una herramienta en una distribución
+const (
+	SourceNative  = "native"  // Paquete del gestor de la distro
+	SourceFlatpak = "flatpak" // Aplicación de Flathub
+	SourceScript  = "script"  // Comando de instalación (curl | sh, cargo, etc.)
+	SourceSkip    = "skip"    // No disponible en esta distro
+)
+
+// sharedListDir es el directorio de listas compartidas entre distribuciones
+const sharedListDir = "common"
+
+// Resolution indica cómo obtener una herramienta canónica en una distribución concreta
+type Resolution struct {
+	Tool    string // Nombre canónico usado en la lista compartida
+	Package string // Nombre del paquete, ID de Flatpak o comando de instalación
+	Source  string
+}
+
+// Mapping traduce nombres canónicos a nombres por distribución. Las claves de
+// cada herramienta son gestores de paquetes ("apt", "dnf"), que cubren a todas
+// las distros que los usan, o directorios de listas ("ubuntu", "mint") para
+// diferencias de una distro concreta, que tienen prioridad.
+// Los valores siguen la sintaxis de mapping.toml:
+//
+//	"fd-find"              paquete nativo con otro nombre
+//	"flatpak:com.app.Id"   aplicación Flatpak
+//	"script:curl ... | sh" comando de instalación
+//	"-"                    no disponible
+type Mapping map[string]map[string]string
+
+// defaultMapping contiene las diferencias conocidas entre Arch, las distros basadas en apt y Fedora
+var defaultMapping = Mapping{
+	"fd": {
+		"apt": "fd-find",
+		"dnf": "fd-find",
+	},
+	"github-cli": {
+		"apt": "gh",
+		"dnf": "gh",
+	},
+	"base-devel": {
+		"apt": "build-essential",
+		"dnf": "@development-tools",
+	},
+	"openssh": {
+		"apt": "openssh-client",
+		"dnf": "openssh-clients",
+	},
+	"python-pip": {
+		"apt": "python3-pip",
+		"dnf": "python3-pip",
+	},
+	"starship": {
+		"apt": "script:curl -sS https://starship.rs/install.sh | sh -s -- -y",
+	},
+	"yazi": {
+		"apt": "-",
+	},
+}
+
+// LoadMapping combina la tabla por defecto con packages/common/mapping.toml del repo dotfiles
+func LoadMapping() (Mapping, error) {
+	mapping := make(Mapping)
+	for tool, distros := range defaultMapping {
+		mapping[tool] = make(map[string]string)
+		for distro, value := range distros {
+			mapping[tool][distro] = value
+		}
+	}
+
+	mappingPath := filepath.Join(utils.GetDotfilesDir(), "packages", sharedListDir, "mapping.toml")
+	data, err := os.ReadFile(mappingPath)
+	if os.IsNotExist(err) {
+		return mapping, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides Mapping
+	if _, err := toml.Decode(string(data), &overrides); err != nil {
+		return nil, fmt.Errorf(i18n.T("common.read_error"), mappingPath, err)
+	}
+
+	for tool, distros := range overrides {
+		if mapping[tool] == nil {
+			mapping[tool] = make(map[string]string)
+		}
+		for distro, value := range distros {
+			mapping[tool][distro] = value
+		}
+	}
+
+	return mapping, nil
+}
+
+// Resolve obtiene cómo instalar una herramienta canónica en la distro con el
+// directorio de listas listDir y el gestor manager. Si no hay entrada en la
+// tabla, se asume que el paquete nativo tiene el mismo nombre.
+func (m Mapping) Resolve(tool string, listDir string, manager string) Resolution {
+	value, ok := m[tool][listDir]
+	if !ok || value == "" {
+		value, ok = m[tool][manager]
+	}
+	if !ok || value == "" {
+		return Resolution{Tool: tool, Package: tool, Source: SourceNative}
+	}
+
+	switch {
+	case value == "-":
+		return Resolution{Tool: tool, Source: SourceSkip}
+	case strings.HasPrefix(value, "flatpak:"):
+		return Resolution{Tool: tool, Package: strings.TrimPrefix(value, "flatpak:"), Source: SourceFlatpak}
+	case strings.HasPrefix(value, "script:"):
+		return Resolution{Tool: tool, Package: strings.TrimPrefix(value, "script:"), Source: SourceScript}
+	default:
+		return Resolution{Tool: tool, Package: value, Source: SourceNative}
+	}
+}
+
+// ResolveGroups traduce los grupos de una lista compartida a nombres nativos de la distro.
+// Las herramientas que requieren otra fuente se retornan aparte como alternativas.
+func (m Mapping) ResolveGroups(groups []PackageGroup, listDir string, manager string) ([]PackageGroup, []Resolution) {
+	var native []PackageGroup
+	var alternates []Resolution
+
+	for _, group := range groups {
+		resolved := PackageGroup{Name: group.Name}
+		for _, tool := range group.Packages {
+			res := m.Resolve(tool, listDir, manager)
+			switch res.Source {
+			case SourceNative:
+				resolved.Packages = append(resolved.Packages, res.Package)
+			case SourceFlatpak, SourceScript:
+				alternates = append(alternates, res)
+			}
+		}
+		if len(resolved.Packages) > 0 {
+			native = append(native, resolved)
+		}
+	}
+
+	return native, alternates
+}
+
+// LoadDistroList carga una lista de paquetes para la distro con el directorio
+// listDir y el gestor manager. Si existe packages/<listDir>/<filename> se usa tal
+// cual; si no, se usa la lista compartida packages/common/<filename> traducida
+// con la tabla de nombres.
+func LoadDistroList(listDir string, manager string, filename string) ([]PackageGroup, []Resolution, error) {
+	groups, err := ParseLST(listDir, filename)
+	if err == nil {
+		return groups, nil, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+
+	shared, sharedErr := ParseLST(sharedListDir, filename)
+	if sharedErr != nil {
+		// Reportar el error original de la lista específica
+		return nil, nil, err
+	}
+
+	mapping, mapErr := LoadMapping()
+	if mapErr != nil {
+		return nil, nil, mapErr
+	}
+
+	native, alternates := mapping.ResolveGroups(shared, listDir, manager)
+	return native, alternates, nil
+}
+
+// PendingAlternates filtra las alternativas que ya están presentes en el sistema
+func PendingAlternates(alternates []Resolution) []Resolution {
+	var flatpakIDs []string
+	for _, res := range alternates {
+		if res.Source == SourceFlatpak {
+			flatpakIDs = append(flatpakIDs, res.Package)
+		}
+	}
+
+	var flatpakInstalled map[string]bool
+	if len(flatpakIDs) > 0 && utils.CommandExists("flatpak") {
+		flatpakInstalled = CheckInstalledFlatpak(flatpakIDs)
+	}
+
+	var pending []Resolution
+	for _, res := range alternates {
+		switch res.Source {
+		case SourceFlatpak:
+			if flatpakInstalled[res.Package] {
+				continue
+			}
+		case SourceScript:
+			// Se asume que el script instala un binario con el nombre canónico
+			if utils.CommandExists(res.Tool) {
+				continue
+			}
+		}
+		pending = append(pending, res)
+	}
+
+	return pending
+}
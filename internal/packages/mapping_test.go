@@ -0,0 +1,27 @@
+package packages
+
+import "testing"
+
+func TestMappingResolve(t *testing.T) {
+	mapping := Mapping{
+		"fd":       {"apt": "fd-find"},
+		"obsidian": {"apt": "flatpak:md.obsidian.Obsidian", "ubuntu": "-"},
+	}
+
+	tests := []struct {
+		tool, listDir, manager string
+		want                   Resolution
+	}{
+		{"fd", "debian", "apt", Resolution{Tool: "fd", Package: "fd-find", Source: SourceNative}},
+		{"fd", "mint", "apt", Resolution{Tool: "fd", Package: "fd-find", Source: SourceNative}},
+		{"fd", "fedora", "dnf", Resolution{Tool: "fd", Package: "fd", Source: SourceNative}},
+		{"obsidian", "debian", "apt", Resolution{Tool: "obsidian", Package: "md.obsidian.Obsidian", Source: SourceFlatpak}},
+		{"obsidian", "ubuntu", "apt", Resolution{Tool: "obsidian", Source: SourceSkip}},
+	}
+
+	for _, tt := range tests {
+		if got := mapping.Resolve(tt.tool, tt.listDir, tt.manager); got != tt.want {
+			t.Errorf("Resolve(%q, %q, %q) = %+v, se esperaba %+v", tt.tool, tt.listDir, tt.manager, got, tt.want)
+		}
+	}
+}
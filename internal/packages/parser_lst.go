@@ -2,32 +2,154 @@ package packages
 
 import (
 	"bufio"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/utils"
 )
 
 // ParseLST lee un archivo .lst de paquetes para una distribución específica
 // El formato .lst es simple: un paquete por línea, comentarios con #, secciones con ===
+//
+// Además admite composición de listas:
+//
+//	@include otra.lst   incluye otra lista (relativa al archivo actual)
+//	!paquete            excluye un paquete de lo leído hasta el final del archivo
+//
+// Si existe ~/.config/orgmos/packages/[<distro>/]<nombre>.local.lst se mezcla
+// automáticamente sobre la lista compartida. Las exclusiones se aplican en el
+// orden de composición: las de un archivo afectan a lo que él y sus inclusiones
+// agregaron, y lo que agregue después el archivo que lo incluye, o el overlay,
+// vuelve a la lista.
 func ParseLST(distro string, filename string) ([]PackageGroup, error) {
 	dotfilesDir := utils.GetDotfilesDir()
-	
+
 	// Asegurar que el filename tenga extensión .lst
 	if !strings.HasSuffix(filename, ".lst") {
 		filename = filename + ".lst"
 	}
-	
+
 	filePath := filepath.Join(dotfilesDir, "packages", distro, filename)
 
-	data, err := os.ReadFile(filePath)
-	if err != nil {
+	list := newLSTList()
+	if err := list.parseFile(filePath, nil, ""); err != nil {
 		return nil, err
 	}
 
+	// Overlay personal del usuario
+	if overlayPath := findLocalOverlay(distro, filename); overlayPath != "" {
+		if err := list.parseFile(overlayPath, nil, ""); err != nil {
+			return nil, fmt.Errorf(i18n.T("packages.overlay_read_error"), overlayPath, err)
+		}
+	}
+
+	return list.result(), nil
+}
+
+// findLocalOverlay busca <nombre>.local.lst en ~/.config/orgmos/packages/<distro>/
+// y luego en ~/.config/orgmos/packages/
+func findLocalOverlay(distro string, filename string) string {
+	overlayName := strings.TrimSuffix(filename, ".lst") + ".local.lst"
+	baseDir := filepath.Join(utils.GetOrgmosConfigDir(), "packages")
+
+	for _, candidate := range []string{
+		filepath.Join(baseDir, distro, overlayName),
+		filepath.Join(baseDir, overlayName),
+	} {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+// lstList acumula los grupos mientras se leen una lista y sus inclusiones
+type lstList struct {
+	groups []*PackageGroup
+	byName map[string]*PackageGroup
+	seen   map[string]bool
+}
+
+func newLSTList() *lstList {
+	return &lstList{
+		byName: make(map[string]*PackageGroup),
+		seen:   make(map[string]bool),
+	}
+}
+
+// add agrega un paquete a un grupo; las secciones con el mismo nombre se combinan
+func (l *lstList) add(groupName string, pkg string) {
+	if l.seen[pkg] {
+		return
+	}
+	l.seen[pkg] = true
+
+	group, ok := l.byName[groupName]
+	if !ok {
+		group = &PackageGroup{Name: groupName, Packages: []string{}}
+		l.byName[groupName] = group
+		l.groups = append(l.groups, group)
+	}
+	group.Packages = append(group.Packages, pkg)
+}
+
+// exclude quita un paquete de lo leído hasta ahora; se puede volver a agregar
+func (l *lstList) exclude(pkg string) {
+	if !l.seen[pkg] {
+		return
+	}
+	delete(l.seen, pkg)
+
+	for _, group := range l.groups {
+		if i := slices.Index(group.Packages, pkg); i >= 0 {
+			group.Packages = slices.Delete(group.Packages, i, i+1)
+			return
+		}
+	}
+}
+
+// result retorna los grupos que quedaron con paquetes
+func (l *lstList) result() []PackageGroup {
 	var groups []PackageGroup
-	var currentGroup *PackageGroup
+	for _, group := range l.groups {
+		if len(group.Packages) > 0 {
+			groups = append(groups, PackageGroup{Name: group.Name, Packages: group.Packages})
+		}
+	}
+	return groups
+}
+
+// parseFile lee un archivo .lst; stack contiene los archivos que lo incluyen
+// para detectar ciclos. group es la sección en curso del archivo que lo incluye:
+// los paquetes anteriores a la primera sección propia van a ella.
+func (l *lstList) parseFile(filePath string, stack []string, group string) error {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		absPath = filePath
+	}
+
+	for _, parent := range stack {
+		if parent == absPath {
+			chain := make([]string, 0, len(stack)+1)
+			for _, p := range append(stack, absPath) {
+				chain = append(chain, filepath.Base(p))
+			}
+			return fmt.Errorf(i18n.T("packages.cyclic_include"), strings.Join(chain, " -> "))
+		}
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	currentGroup := group
+	var excluded []string
 	scanner := bufio.NewScanner(strings.NewReader(string(data)))
 
 	for scanner.Scan() {
@@ -42,24 +164,13 @@ func ParseLST(distro string, filename string) ([]PackageGroup, error) {
 		if strings.HasPrefix(line, "#") {
 			// Verificar si es una sección (===)
 			if strings.Contains(line, "===") {
-				// Es una sección, crear nuevo grupo
-				// Extraer nombre de sección (quitar # y ===)
+				// Es una sección, extraer nombre (quitar # y ===)
 				sectionName := strings.TrimSpace(line)
 				sectionName = strings.TrimPrefix(sectionName, "#")
 				sectionName = strings.TrimSpace(sectionName)
 				sectionName = strings.Trim(sectionName, "=")
 				sectionName = strings.TrimSpace(sectionName)
-
-				// Si hay un grupo anterior con paquetes, agregarlo
-				if currentGroup != nil && len(currentGroup.Packages) > 0 {
-					groups = append(groups, *currentGroup)
-				}
-
-				// Crear nuevo grupo
-				currentGroup = &PackageGroup{
-					Name:     sectionName,
-					Packages: []string{},
-				}
+				currentGroup = sectionName
 			}
 			// Si no es sección, es un comentario normal, ignorar
 			continue
@@ -70,29 +181,52 @@ func ParseLST(distro string, filename string) ([]PackageGroup, error) {
 			line = strings.TrimSpace(line[:idx])
 		}
 
-		// Si la línea no está vacía después de quitar comentarios, es un paquete
-		if line != "" {
-			// Si no hay grupo actual, crear uno por defecto
-			if currentGroup == nil {
-				currentGroup = &PackageGroup{
-					Name:     "Paquetes",
-					Packages: []string{},
-				}
+		if line == "" {
+			continue
+		}
+
+		// Inclusión de otra lista, relativa al archivo actual
+		if include, ok := strings.CutPrefix(line, "@include"); ok {
+			include = strings.TrimSpace(include)
+			if include == "" {
+				continue
+			}
+			if !strings.HasSuffix(include, ".lst") {
+				include += ".lst"
+			}
+			includePath := include
+			if !filepath.IsAbs(includePath) {
+				includePath = filepath.Join(filepath.Dir(filePath), include)
 			}
-			currentGroup.Packages = append(currentGroup.Packages, line)
+			if err := l.parseFile(includePath, append(stack, absPath), currentGroup); err != nil {
+				return fmt.Errorf("%s: @include %s: %w", filepath.Base(filePath), include, err)
+			}
+			continue
 		}
-	}
 
-	// Agregar el último grupo si tiene paquetes
-	if currentGroup != nil && len(currentGroup.Packages) > 0 {
-		groups = append(groups, *currentGroup)
-	}
+		// Exclusión de un paquete, que se aplica al terminar el archivo
+		if pkg, ok := strings.CutPrefix(line, "!"); ok {
+			if pkg = strings.TrimSpace(pkg); pkg != "" {
+				excluded = append(excluded, pkg)
+			}
+			continue
+		}
 
+		// Si no hay grupo actual, usar uno por defecto
+		groupName := currentGroup
+		if groupName == "" {
+			groupName = i18n.T("packages.default_group")
+		}
+		l.add(groupName, line)
+	}
 	if err := scanner.Err(); err != nil {
-		return nil, err
+		return err
 	}
 
-	return groups, nil
+	for _, pkg := range excluded {
+		l.exclude(pkg)
+	}
+	return nil
 }
 
 // GetAllPackagesLST obtiene todos los paquetes de un archivo .lst para una distribución
@@ -109,4 +243,3 @@ func GetAllPackagesLST(distro string, filename string) ([]string, error) {
 
 	return all, nil
 }
-
@@ -0,0 +1,175 @@
+package packages
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"orgmos/internal/i18n"
+)
+
+// writeLists crea los archivos indicados (nombre -> contenido) en un directorio temporal
+func writeLists(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestParseFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		overlay string // Archivo que se mezcla después, como un .local.lst
+		want    []PackageGroup
+		wantErr string
+	}{
+		{
+			name: "secciones y comentarios",
+			files: map[string]string{
+				"main.lst": "# === Terminal ===\nfish # shell\nkitty\n\n# comentario\n# === Red ===\nnmap\n",
+			},
+			want: []PackageGroup{
+				{Name: "Terminal", Packages: []string{"fish", "kitty"}},
+				{Name: "Red", Packages: []string{"nmap"}},
+			},
+		},
+		{
+			name: "sin sección usa el grupo por defecto",
+			files: map[string]string{
+				"main.lst": "git\n",
+			},
+			want: []PackageGroup{{Name: i18n.T("packages.default_group"), Packages: []string{"git"}}},
+		},
+		{
+			name: "la inclusión sin sección hereda la sección en curso",
+			files: map[string]string{
+				"main.lst":   "# === Base ===\ngit\n@include common\n# === Red ===\nnmap\n",
+				"common.lst": "curl\nwget\n",
+			},
+			want: []PackageGroup{
+				{Name: "Base", Packages: []string{"git", "curl", "wget"}},
+				{Name: "Red", Packages: []string{"nmap"}},
+			},
+		},
+		{
+			name: "la sección de la inclusión no cambia la del archivo que incluye",
+			files: map[string]string{
+				"main.lst":      "# === Base ===\n@include sub/dev.lst\ngit\n",
+				"sub/dev.lst":   "# === Desarrollo ===\ngo\n@include tools\n",
+				"sub/tools.lst": "make\n",
+			},
+			want: []PackageGroup{
+				{Name: "Desarrollo", Packages: []string{"go", "make"}},
+				{Name: "Base", Packages: []string{"git"}},
+			},
+		},
+		{
+			name: "exclusiones y duplicados entre archivos",
+			files: map[string]string{
+				"main.lst":   "# === Base ===\n@include common\n!wget\ncurl\n",
+				"common.lst": "curl\nwget\nvim\n",
+			},
+			want: []PackageGroup{{Name: "Base", Packages: []string{"curl", "vim"}}},
+		},
+		{
+			name: "el overlay agrega, combina secciones y excluye",
+			files: map[string]string{
+				"main.lst":  "# === Base ===\ngit\nnano\n# === Red ===\nnmap\n",
+				"local.lst": "!nano\n# === Base ===\nneovim\n# === Personal ===\nbtop\n",
+			},
+			overlay: "local.lst",
+			want: []PackageGroup{
+				{Name: "Base", Packages: []string{"git", "neovim"}},
+				{Name: "Red", Packages: []string{"nmap"}},
+				{Name: "Personal", Packages: []string{"btop"}},
+			},
+		},
+		{
+			name: "la exclusión vale para todo el archivo, aun antes de la inclusión",
+			files: map[string]string{
+				"main.lst":   "!wget\n# === Base ===\n@include common\n",
+				"common.lst": "curl\nwget\n",
+			},
+			want: []PackageGroup{{Name: "Base", Packages: []string{"curl"}}},
+		},
+		{
+			name: "el archivo que incluye puede volver a agregar lo excluido",
+			files: map[string]string{
+				"main.lst":   "# === Base ===\n@include common\nwget\n",
+				"common.lst": "curl\nwget\n!wget\n",
+			},
+			want: []PackageGroup{{Name: "Base", Packages: []string{"curl", "wget"}}},
+		},
+		{
+			name: "el overlay puede volver a agregar lo que excluye la lista base",
+			files: map[string]string{
+				"main.lst":  "# === Base ===\ngit\nnano\n!nano\n",
+				"local.lst": "# === Personal ===\nnano\n",
+			},
+			overlay: "local.lst",
+			want: []PackageGroup{
+				{Name: "Base", Packages: []string{"git"}},
+				{Name: "Personal", Packages: []string{"nano"}},
+			},
+		},
+		{
+			name: "una lista vacía tras excluir no aparece",
+			files: map[string]string{
+				"main.lst": "# === Base ===\ngit\n!git\n# === Red ===\nnmap\n",
+			},
+			want: []PackageGroup{{Name: "Red", Packages: []string{"nmap"}}},
+		},
+		{
+			name: "inclusión cíclica",
+			files: map[string]string{
+				"main.lst": "@include a\n",
+				"a.lst":    "@include b\n",
+				"b.lst":    "@include a\n",
+			},
+			wantErr: "a.lst -> b.lst -> a.lst",
+		},
+		{
+			name: "inclusión inexistente",
+			files: map[string]string{
+				"main.lst": "@include missing\n",
+			},
+			wantErr: "@include missing.lst",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeLists(t, tt.files)
+
+			list := newLSTList()
+			err := list.parseFile(filepath.Join(dir, "main.lst"), nil, "")
+			if err == nil && tt.overlay != "" {
+				err = list.parseFile(filepath.Join(dir, tt.overlay), nil, "")
+			}
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error %v, se esperaba uno con %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := list.result(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("grupos %v, se esperaba %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,330 @@
+package packages
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/ui"
+	"orgmos/internal/utils"
+)
+
+// Etapas de una instalación
+const (
+	phaseDownload = "download"
+	phaseBuild    = "build"
+	phaseInstall  = "install"
+)
+
+// Los patrones son los mensajes en inglés: las corridas con vista de progreso
+// se ejecutan con LC_ALL=C.UTF-8 (ver trackedCommand)
+var (
+	// pacman (también paru y yay, que lo usan por debajo)
+	pacmanCountRe    = regexp.MustCompile(`^Packages \((\d+)\)`)
+	pacmanDownloadRe = regexp.MustCompile(`^(\S+) downloading\.\.\.`)
+	pacmanInstallRe  = regexp.MustCompile(`^\((\d+)/(\d+)\) (?:installing|upgrading|reinstalling|downgrading) (\S+?)(?:\.\.\.)?(?:\s|$)`)
+	aurCountRe       = regexp.MustCompile(`^Aur \((\d+)\)`)
+	makepkgRe        = regexp.MustCompile(`^==> Making package: (\S+)`)
+
+	// apt con -o APT::Status-Fd=1: "dlstatus:<n>:<porcentaje>:<texto>" y
+	// "pmstatus:<paquete>:<porcentaje>:<texto>"
+	aptDownloadRe = regexp.MustCompile(`^dlstatus:\d+:([\d.]+):`)
+	aptInstallRe  = regexp.MustCompile(`^pmstatus:([^:]+):([\d.]+):`)
+
+	// dnf 4 y dnf5
+	dnfDownloadRe  = regexp.MustCompile(`^\((\d+)/(\d+)\): (\S+)`)
+	dnfInstallRe   = regexp.MustCompile(`^(?:Installing|Upgrading|Reinstalling)\s*: (\S+)\s+(\d+)/(\d+)$`)
+	dnf5DownloadRe = regexp.MustCompile(`^\[\s*(\d+)/(\d+)\] (\S+:\S+)`)
+	dnf5InstallRe  = regexp.MustCompile(`^\[\s*(\d+)/(\d+)\] (?:Installing|Upgrading|Reinstalling) (\S+)`)
+
+	// flatpak: la tabla inicial "1. [✓] org.app.Id ..." y "Installing 2/3… 45%"
+	flatpakRefRe     = regexp.MustCompile(`^(\d+)\.\s+(?:\[.\]\s+)?(\S+\.\S+\.\S+)\s`)
+	flatpakInstallRe = regexp.MustCompile(`(?:Installing|Updating) (\d+)/(\d+)`)
+
+	percentRe = regexp.MustCompile(`(\d+)%`)
+)
+
+// progressTracker interpreta la salida de un gestor de paquetes para ui.ProgressView
+type progressTracker struct {
+	manager  string
+	progress ui.Progress
+	phases   map[string]int // Etapa -> índice en progress.Phases
+
+	downloads   int               // Descargas de pacman empezadas
+	builds      int               // Compilaciones de AUR empezadas
+	flatpakRefs map[string]string // Número en la tabla de flatpak -> aplicación
+}
+
+// newProgressTracker crea el intérprete para manager: "pacman", "paru", "yay",
+// "apt", "dnf" o "flatpak"
+func newProgressTracker(manager string) *progressTracker {
+	t := &progressTracker{
+		manager:     manager,
+		progress:    ui.Progress{Active: -1},
+		phases:      make(map[string]int),
+		flatpakRefs: make(map[string]string),
+	}
+
+	phases := []string{phaseDownload, phaseInstall}
+	switch manager {
+	case "paru", "yay":
+		phases = []string{phaseDownload, phaseBuild, phaseInstall}
+	case "flatpak":
+		// flatpak descarga e instala cada aplicación en un solo paso
+		phases = []string{phaseInstall}
+	}
+
+	titles := map[string]string{
+		phaseDownload: i18n.T("packages.phase_download"),
+		phaseBuild:    i18n.T("packages.phase_build"),
+		phaseInstall:  i18n.T("packages.phase_install"),
+	}
+	for _, phase := range phases {
+		t.phases[phase] = len(t.progress.Phases)
+		t.progress.Phases = append(t.progress.Phases, ui.ProgressPhase{Name: titles[phase]})
+	}
+	return t
+}
+
+func (t *progressTracker) Progress() ui.Progress {
+	return t.progress
+}
+
+// phase retorna la etapa name, o nil si este gestor no la tiene
+func (t *progressTracker) phase(name string) *ui.ProgressPhase {
+	idx, ok := t.phases[name]
+	if !ok {
+		return nil
+	}
+	return &t.progress.Phases[idx]
+}
+
+// start marca name como la etapa en curso. Empezar a instalar implica que
+// terminaron las descargas.
+func (t *progressTracker) start(name string) *ui.ProgressPhase {
+	phase := t.phase(name)
+	if phase == nil {
+		return nil
+	}
+	if name == phaseInstall {
+		if download := t.phase(phaseDownload); download != nil && download.Started {
+			download.Done = true
+		}
+	}
+	phase.Started = true
+	t.progress.Active = t.phases[name]
+	return phase
+}
+
+func (t *progressTracker) Feed(line string, partial bool) bool {
+	line = strings.TrimSpace(line)
+
+	switch t.manager {
+	case "pacman", "paru", "yay":
+		t.feedPacman(line)
+	case "apt":
+		return t.feedApt(line)
+	case "dnf":
+		t.feedDnf(line)
+	case "flatpak":
+		t.feedFlatpak(line)
+	}
+	return true
+}
+
+func (t *progressTracker) feedPacman(line string) {
+	if m := pacmanCountRe.FindStringSubmatch(line); m != nil {
+		total, _ := strconv.Atoi(m[1])
+		for _, name := range []string{phaseDownload, phaseInstall} {
+			if phase := t.phase(name); phase != nil && phase.Total == 0 {
+				phase.Total = total
+			}
+		}
+		return
+	}
+
+	if m := aurCountRe.FindStringSubmatch(line); m != nil {
+		if build := t.phase(phaseBuild); build != nil {
+			build.Total, _ = strconv.Atoi(m[1])
+		}
+		return
+	}
+
+	if m := pacmanDownloadRe.FindStringSubmatch(line); m != nil {
+		download := t.start(phaseDownload)
+		t.downloads++
+		download.Current = t.downloads - 1
+		t.progress.Package = m[1]
+		return
+	}
+
+	if m := makepkgRe.FindStringSubmatch(line); m != nil {
+		if build := t.start(phaseBuild); build != nil {
+			t.builds++
+			build.Current = t.builds - 1
+			t.progress.Package = m[1]
+		}
+		return
+	}
+
+	if m := pacmanInstallRe.FindStringSubmatch(line); m != nil {
+		install := t.start(phaseInstall)
+		current, _ := strconv.Atoi(m[1])
+		install.Total, _ = strconv.Atoi(m[2])
+		install.Current = current - 1
+		install.Percent = linePercent(line)
+		t.progress.Package = m[3]
+	}
+}
+
+// feedApt procesa las líneas de APT::Status-Fd, que no se muestran en la salida
+func (t *progressTracker) feedApt(line string) bool {
+	if m := aptDownloadRe.FindStringSubmatch(line); m != nil {
+		percent, _ := strconv.ParseFloat(m[1], 64)
+		t.start(phaseDownload).Percent = percent / 100
+		return false
+	}
+
+	if m := aptInstallRe.FindStringSubmatch(line); m != nil {
+		percent, _ := strconv.ParseFloat(m[2], 64)
+		t.start(phaseInstall).Percent = percent / 100
+		t.progress.Package = m[1]
+		return false
+	}
+
+	return true
+}
+
+func (t *progressTracker) feedDnf(line string) {
+	// dnf 4 muestra cada descarga al terminarla
+	if m := dnfDownloadRe.FindStringSubmatch(line); m != nil {
+		download := t.start(phaseDownload)
+		download.Current, _ = strconv.Atoi(m[1])
+		download.Total, _ = strconv.Atoi(m[2])
+		t.progress.Package = m[3]
+		return
+	}
+
+	if m := dnfInstallRe.FindStringSubmatch(line); m != nil {
+		install := t.start(phaseInstall)
+		current, _ := strconv.Atoi(m[2])
+		install.Total, _ = strconv.Atoi(m[3])
+		install.Current = current - 1
+		t.progress.Package = m[1]
+		return
+	}
+
+	if m := dnf5InstallRe.FindStringSubmatch(line); m != nil {
+		install := t.start(phaseInstall)
+		current, _ := strconv.Atoi(m[1])
+		install.Total, _ = strconv.Atoi(m[2])
+		install.Current = current - 1
+		install.Percent = linePercent(line)
+		t.progress.Package = m[3]
+		return
+	}
+
+	if m := dnf5DownloadRe.FindStringSubmatch(line); m != nil {
+		download := t.start(phaseDownload)
+		current, _ := strconv.Atoi(m[1])
+		download.Total, _ = strconv.Atoi(m[2])
+		download.Current = current - 1
+		download.Percent = linePercent(line)
+		t.progress.Package = m[3]
+	}
+}
+
+func (t *progressTracker) feedFlatpak(line string) {
+	if m := flatpakRefRe.FindStringSubmatch(line); m != nil {
+		t.flatpakRefs[m[1]] = m[2]
+		return
+	}
+
+	if m := flatpakInstallRe.FindStringSubmatch(line); m != nil {
+		install := t.start(phaseInstall)
+		current, _ := strconv.Atoi(m[1])
+		install.Total, _ = strconv.Atoi(m[2])
+		install.Current = current - 1
+		install.Percent = linePercent(line)
+		if ref, ok := t.flatpakRefs[m[1]]; ok {
+			t.progress.Package = ref
+		}
+	}
+}
+
+// linePercent retorna el último porcentaje de una línea de progreso, de 0 a 1
+func linePercent(line string) float64 {
+	matches := percentRe.FindAllStringSubmatch(line, -1)
+	if len(matches) == 0 {
+		return 0
+	}
+	percent, _ := strconv.Atoi(matches[len(matches)-1][1])
+	return min(float64(percent)/100, 1)
+}
+
+// progressSafe indica si una corrida de manager puede mostrarse en la vista de
+// progreso: sus comandos no reciben la terminal, así que nada debe pedir la
+// contraseña a mitad de camino
+func progressSafe(manager string) bool {
+	if !ui.ProgressAvailable() {
+		return false
+	}
+
+	switch manager {
+	case "paru", "yay":
+		// Corren como el usuario y llaman a sudo por su cuenta
+		return !utils.IsRoot() && utils.PrivilegesCached()
+	case "flatpak":
+		// La instalación del sistema la autoriza polkit, con un agente gráfico
+		return utils.IsRoot() || os.Getenv("WAYLAND_DISPLAY") != "" || os.Getenv("DISPLAY") != ""
+	default:
+		return utils.PrivilegesCached()
+	}
+}
+
+// progressTitle es el título de la vista de progreso de una corrida
+func progressTitle(manager string, packages []string) string {
+	return i18n.T("packages.progress_title", manager, SummarizePackages(packages))
+}
+
+// trackedCommand retorna el comando de una corrida que escribe en out. Con vista
+// de progreso se ejecuta bajo "env LC_ALL=C.UTF-8" para que el gestor escriba
+// en inglés lo que interpreta progressTracker; env pasa la variable aun a través
+// de sudo, que limpia el entorno. Sin vista (out nil) no cambia nada.
+func trackedCommand(out io.Writer, name string, args []string) (string, []string) {
+	if out == nil {
+		return name, args
+	}
+	return "env", append(append(slices.Clone(untranslatedEnv), name), args...)
+}
+
+// runWithProgress ejecuta una corrida de manager mostrando su avance cuando la
+// terminal lo permite; si no, la salida se ve como siempre. run recibe dónde
+// escribir la salida de sus comandos (nil para la terminal) y los argumentos
+// extra que el gestor necesita para informar el avance.
+func runWithProgress(manager string, title string, run func(out io.Writer, progressArgs []string) (string, error)) (string, error) {
+	if !progressSafe(manager) {
+		return run(nil, nil)
+	}
+
+	var progressArgs []string
+	if manager == "apt" {
+		progressArgs = []string{"-o", "APT::Status-Fd=1"}
+	}
+
+	view := ui.NewProgressView(title, newProgressTracker(manager))
+	removeCleanup := utils.OnCancel(view.Stop)
+	defer removeCleanup()
+
+	var output string
+	err := view.Run(func(out io.Writer) error {
+		var err error
+		output, err = run(out, progressArgs)
+		return err
+	})
+	return output, err
+}
@@ -0,0 +1,183 @@
+package packages
+
+import (
+	"io"
+	"math"
+	"reflect"
+	"testing"
+
+	"orgmos/internal/ui"
+)
+
+func TestProgressTracker(t *testing.T) {
+	tests := []struct {
+		name         string
+		manager      string
+		lines        []string
+		wantHidden   []string // Líneas que no deben aparecer en la salida
+		wantPhases   []ui.ProgressPhase
+		wantActive   int
+		wantPackage  string
+		wantFraction float64
+	}{
+		{
+			name:    "pacman descarga e instala",
+			manager: "pacman",
+			lines: []string{
+				"Packages (2) foo-1.0-1  bar-2.0-1",
+				":: Retrieving packages...",
+				" foo-1.0-1-x86_64 downloading...",
+				" bar-2.0-1-x86_64 downloading...",
+				"(1/2) installing foo                      [######################] 100%",
+			},
+			wantPhases: []ui.ProgressPhase{
+				{Current: 1, Total: 2, Started: true, Done: true},
+				{Current: 0, Total: 2, Percent: 1, Started: true},
+			},
+			wantActive:   1,
+			wantPackage:  "foo",
+			wantFraction: 0.75,
+		},
+		{
+			name:    "pacman sin descargas: todo en caché",
+			manager: "pacman",
+			lines: []string{
+				"Packages (1) foo-1.0-1",
+				"(1/1) installing foo                      [######################] 100%",
+			},
+			wantPhases: []ui.ProgressPhase{
+				{Total: 1},
+				{Current: 0, Total: 1, Percent: 1, Started: true},
+			},
+			wantActive:   1,
+			wantPackage:  "foo",
+			wantFraction: 1,
+		},
+		{
+			name:    "paru compila del AUR",
+			manager: "paru",
+			lines: []string{
+				"Aur (1) baz-git-r1.abc-1",
+				"==> Making package: baz-git r1.abc-1 (Sat 18 Oct 2026)",
+			},
+			wantPhases: []ui.ProgressPhase{
+				{},
+				{Current: 0, Total: 1, Started: true},
+				{},
+			},
+			wantActive:   1,
+			wantPackage:  "baz-git",
+			wantFraction: 1.0 / 3,
+		},
+		{
+			name:    "apt con Status-Fd",
+			manager: "apt",
+			lines: []string{
+				"Reading package lists...",
+				"dlstatus:1:50.0:Retrieving file 1 of 2",
+				"pmstatus:curl:75.0:Installing curl",
+			},
+			wantHidden: []string{"dlstatus:1:50.0:Retrieving file 1 of 2", "pmstatus:curl:75.0:Installing curl"},
+			wantPhases: []ui.ProgressPhase{
+				{Percent: 0.5, Started: true, Done: true},
+				{Percent: 0.75, Started: true},
+			},
+			wantActive:   1,
+			wantPackage:  "curl",
+			wantFraction: 0.875,
+		},
+		{
+			name:    "dnf 4",
+			manager: "dnf",
+			lines: []string{
+				"(1/2): foo-1.0-1.fc40.x86_64.rpm         1.0 MB/s | 100 kB     00:00",
+				"  Installing       : foo-1.0-1.fc40.x86_64                   1/2",
+			},
+			wantPhases: []ui.ProgressPhase{
+				{Current: 1, Total: 2, Started: true, Done: true},
+				{Current: 0, Total: 2, Started: true},
+			},
+			wantActive:   1,
+			wantPackage:  "foo-1.0-1.fc40.x86_64",
+			wantFraction: 0.5,
+		},
+		{
+			name:    "dnf5",
+			manager: "dnf",
+			lines: []string{
+				"[1/2] foo-0:1.0-1.fc40.x86_64           100% |   1.0 MiB/s | 100.0 KiB |  00m00s",
+				"[2/3] Installing foo-0:1.0-1.fc40.x86_64 100% |  10.0 MiB/s | 300.0 KiB |  00m00s",
+			},
+			wantPhases: []ui.ProgressPhase{
+				{Current: 0, Total: 2, Percent: 1, Started: true, Done: true},
+				{Current: 1, Total: 3, Percent: 1, Started: true},
+			},
+			wantActive:   1,
+			wantPackage:  "foo-0:1.0-1.fc40.x86_64",
+			wantFraction: (1 + 2.0/3) / 2,
+		},
+		{
+			name:    "flatpak",
+			manager: "flatpak",
+			lines: []string{
+				"1.     org.gimp.GIMP    stable    i    flathub   < 100 MB",
+				"Installing 1/1… ████████             45%  1.2 MB/s  00:10",
+			},
+			wantPhases: []ui.ProgressPhase{
+				{Current: 0, Total: 1, Percent: 0.45, Started: true},
+			},
+			wantActive:   0,
+			wantPackage:  "org.gimp.GIMP",
+			wantFraction: 0.45,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newProgressTracker(tt.manager)
+
+			var hidden []string
+			for _, line := range tt.lines {
+				if !tracker.Feed(line, false) {
+					hidden = append(hidden, line)
+				}
+			}
+			if !reflect.DeepEqual(hidden, tt.wantHidden) {
+				t.Errorf("líneas ocultas = %q, se esperaba %q", hidden, tt.wantHidden)
+			}
+
+			progress := tracker.Progress()
+			phases := append([]ui.ProgressPhase(nil), progress.Phases...)
+			for i := range phases {
+				phases[i].Name = ""
+			}
+			if !reflect.DeepEqual(phases, tt.wantPhases) {
+				t.Errorf("etapas = %+v\nse esperaba %+v", phases, tt.wantPhases)
+			}
+			if progress.Active != tt.wantActive {
+				t.Errorf("etapa activa = %d, se esperaba %d", progress.Active, tt.wantActive)
+			}
+			if progress.Package != tt.wantPackage {
+				t.Errorf("paquete = %q, se esperaba %q", progress.Package, tt.wantPackage)
+			}
+			if got := progress.Fraction(); math.Abs(got-tt.wantFraction) > 1e-9 {
+				t.Errorf("avance = %.3f, se esperaba %.3f", got, tt.wantFraction)
+			}
+		})
+	}
+}
+
+func TestTrackedCommand(t *testing.T) {
+	args := []string{"-S", "foo"}
+
+	name, got := trackedCommand(nil, "pacman", args)
+	if name != "pacman" || !reflect.DeepEqual(got, args) {
+		t.Errorf("sin vista: %s %q, se esperaba pacman %q", name, got, args)
+	}
+
+	name, got = trackedCommand(io.Discard, "pacman", args)
+	want := []string{"LC_ALL=C.UTF-8", "pacman", "-S", "foo"}
+	if name != "env" || !reflect.DeepEqual(got, want) {
+		t.Errorf("con vista: %s %q, se esperaba env %q", name, got, want)
+	}
+}
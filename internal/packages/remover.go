@@ -0,0 +1,131 @@
+package packages
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/ui"
+	"orgmos/internal/utils"
+)
+
+// RemovePackages desinstala paquetes con el gestor indicado
+// ("pacman", "apt", "dnf" o "flatpak")
+func RemovePackages(manager string, packages []string) error {
+	if len(packages) == 0 {
+		return nil
+	}
+
+	switch manager {
+	case "pacman":
+		fmt.Println(ui.Info(i18n.T("packages.removing_pacman", len(packages))))
+		args := append([]string{"-Rns", "--noconfirm"}, packages...)
+		return utils.RunCommandWithSudo("pacman", args...)
+	case "apt":
+		fmt.Println(ui.Info(i18n.T("packages.removing_apt", len(packages))))
+		args := append([]string{"purge", "-y", "--autoremove"}, packages...)
+		return utils.RunCommandWithSudo("apt", args...)
+	case "dnf":
+		fmt.Println(ui.Info(i18n.T("packages.removing_dnf", len(packages))))
+		args := append([]string{"remove", "-y"}, packages...)
+		return utils.RunCommandWithSudo("dnf", args...)
+	case "flatpak":
+		fmt.Println(ui.Info(i18n.T("packages.removing_flatpak", len(packages))))
+		args := append([]string{"uninstall", "-y"}, packages...)
+		return utils.RunCommand("flatpak", args...)
+	default:
+		return fmt.Errorf(i18n.T("common.unsupported_manager"), manager)
+	}
+}
+
+// RequiredBy retorna, para cada paquete, los paquetes instalados que dependen de él.
+// Los paquetes de la misma lista no se cuentan porque se desinstalarían juntos.
+func RequiredBy(manager string, packages []string) map[string][]string {
+	removing := make(map[string]bool)
+	for _, pkg := range packages {
+		removing[pkg] = true
+	}
+
+	var dependents map[string][]string
+	switch manager {
+	case "pacman":
+		dependents = requiredByPacman(packages)
+	case "apt":
+		dependents = requiredByApt(packages)
+	case "dnf":
+		dependents = requiredByRpm(packages)
+	default:
+		// Las aplicaciones Flatpak no dependen unas de otras
+		return make(map[string][]string)
+	}
+
+	result := make(map[string][]string)
+	for pkg, users := range dependents {
+		seen := make(map[string]bool)
+		for _, user := range users {
+			if !removing[user] && !seen[user] && user != pkg {
+				seen[user] = true
+				result[pkg] = append(result[pkg], user)
+			}
+		}
+		sort.Strings(result[pkg])
+	}
+	return result
+}
+
+func requiredByPacman(packages []string) map[string][]string {
+	dependents := make(map[string][]string)
+
+	args := append([]string{"-Qi"}, packages...)
+	output, _ := runInfoQuery("pacman", args...)
+
+	for _, block := range strings.Split(output, "\n\n") {
+		fields := parseInfoFields(block)
+		if fields["Name"] == "" || fields["Required By"] == "None" {
+			continue
+		}
+		dependents[fields["Name"]] = strings.Fields(fields["Required By"])
+	}
+	return dependents
+}
+
+func requiredByApt(packages []string) map[string][]string {
+	dependents := make(map[string][]string)
+
+	status, err := ReadDpkgStatus()
+	if err != nil {
+		return dependents
+	}
+
+	wanted := make(map[string]bool)
+	for _, pkg := range packages {
+		wanted[pkg] = true
+	}
+
+	for _, deb := range status {
+		if !deb.IsInstalled() {
+			continue
+		}
+		for _, dep := range deb.Depends {
+			if wanted[dep] {
+				dependents[dep] = append(dependents[dep], deb.Name)
+			}
+		}
+	}
+	return dependents
+}
+
+func requiredByRpm(packages []string) map[string][]string {
+	dependents := make(map[string][]string)
+
+	for _, pkg := range packages {
+		output, err := utils.RunCommandSilent("rpm", "-q", "--whatrequires", "--qf", "%{NAME}\n", pkg)
+		if err != nil {
+			// rpm retorna error cuando nada depende del paquete
+			continue
+		}
+		dependents[pkg] = splitLines(output)
+	}
+	return dependents
+}
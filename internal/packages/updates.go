@@ -0,0 +1,61 @@
+package packages
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/utils"
+)
+
+// PendingUpdates cuenta las actualizaciones pendientes según la base de datos
+// local del gestor, sin sincronizarla: es una consulta rápida que no necesita
+// red ni privilegios. manager puede ser "pacman", "apt" o "dnf".
+func PendingUpdates(manager string) (int, error) {
+	switch manager {
+	case "pacman":
+		// pacman -Qu termina con código 1 y sin salida cuando no hay nada que
+		// actualizar; cualquier otro fallo se informa
+		output, err := utils.RunCommandSilent("pacman", "-Qu")
+		if err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && strings.TrimSpace(output) == "" {
+				return 0, nil
+			}
+			return 0, err
+		}
+		return len(splitLines(output)), nil
+
+	case "apt":
+		// apt list: "<paquete>/<suite> <versión> <arch> [upgradable from: ...]"
+		output, err := utils.RunCommandSilent("apt", "list", "--upgradable")
+		if err != nil {
+			return 0, err
+		}
+		pending := 0
+		for _, line := range splitLines(output) {
+			if strings.Contains(line, "/") && strings.Contains(line, "[") {
+				pending++
+			}
+		}
+		return pending, nil
+
+	case "dnf":
+		// -C usa solo la caché de metadatos; las líneas son "<nombre>.<arch> <versión> <repo>"
+		output, err := utils.RunCommandSilent("dnf", "-C", "-q", "list", "--upgrades")
+		if err != nil {
+			return 0, err
+		}
+		pending := 0
+		for _, line := range splitLines(output) {
+			if fields := strings.Fields(line); len(fields) == 3 && strings.Contains(fields[0], ".") {
+				pending++
+			}
+		}
+		return pending, nil
+	}
+
+	return 0, fmt.Errorf(i18n.T("common.unsupported_manager"), manager)
+}
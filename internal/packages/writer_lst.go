@@ -0,0 +1,101 @@
+package packages
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"orgmos/internal/utils"
+)
+
+// sectionHeader retorna el encabezado de sección en el formato que lee ParseLST
+func sectionHeader(name string) string {
+	return fmt.Sprintf("# === %s ===", name)
+}
+
+// WriteLST escribe grupos de paquetes en el formato que lee ParseLST,
+// con el encabezado opcional que usa DiscoverLists
+func WriteLST(path string, title string, description string, groups []PackageGroup) error {
+	var b strings.Builder
+	if title != "" {
+		b.WriteString("# title: " + title + "\n")
+	}
+	if description != "" {
+		b.WriteString("# description: " + description + "\n")
+	}
+
+	for _, group := range groups {
+		b.WriteString("\n" + sectionHeader(group.Name) + "\n")
+		for _, pkg := range group.Packages {
+			b.WriteString(pkg + "\n")
+		}
+	}
+
+	return utils.WriteFileForUser(path, []byte(strings.TrimPrefix(b.String(), "\n")), 0644)
+}
+
+// ListSections retorna los nombres de las secciones (# === nombre ===) de un .lst
+func ListSections(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var sections []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if name, ok := parseSectionLine(line); ok {
+			sections = append(sections, name)
+		}
+	}
+	return sections, nil
+}
+
+// parseSectionLine extrae el nombre de una línea "# === nombre ==="
+func parseSectionLine(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "#") || !strings.Contains(line, "===") {
+		return "", false
+	}
+	name := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+	return strings.TrimSpace(strings.Trim(name, "=")), true
+}
+
+// AppendToLST agrega paquetes al final de una sección de un .lst existente.
+// Si la sección no existe se crea al final del archivo.
+func AppendToLST(path string, section string, pkgs []string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+
+	// Buscar la sección y la última línea con contenido dentro de ella
+	insertAt := -1
+	for i, line := range lines {
+		if name, ok := parseSectionLine(line); ok {
+			if insertAt >= 0 {
+				break
+			}
+			if name == section {
+				insertAt = i + 1
+			}
+			continue
+		}
+		if insertAt >= 0 && strings.TrimSpace(line) != "" {
+			insertAt = i + 1
+		}
+	}
+
+	var result []string
+	if insertAt < 0 {
+		result = append(lines, "", sectionHeader(section))
+		result = append(result, pkgs...)
+	} else {
+		result = append(result, lines[:insertAt]...)
+		result = append(result, pkgs...)
+		result = append(result, lines[insertAt:]...)
+	}
+
+	return utils.WriteFileForUser(path, []byte(strings.Join(result, "\n")+"\n"), 0644)
+}
@@ -0,0 +1,419 @@
+package preflight
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"orgmos/internal/i18n"
+	"orgmos/internal/packages"
+	"orgmos/internal/ui"
+	"orgmos/internal/utils"
+)
+
+// minFreeSpace es el margen mínimo que debe quedar libre además de lo estimado
+const minFreeSpace = 512 << 20
+
+// mirrorTimeout es el tiempo máximo para conectar con un mirror
+const mirrorTimeout = 3 * time.Second
+
+// Check es el resultado de una verificación previa a la instalación
+type Check struct {
+	Name     string
+	OK       bool
+	Blocking bool // Si falla, la instalación probablemente no terminará
+	Detail   string
+}
+
+// Report es el conjunto de verificaciones de una instalación
+type Report struct {
+	Checks []Check
+}
+
+// Blocked indica si alguna verificación bloqueante falló
+func (r Report) Blocked() bool {
+	for _, check := range r.Checks {
+		if !check.OK && check.Blocking {
+			return true
+		}
+	}
+	return false
+}
+
+// Print muestra el resumen de verificaciones
+func (r Report) Print() {
+	fmt.Println(ui.Info(i18n.T("preflight.title")))
+	for _, check := range r.Checks {
+		line := fmt.Sprintf("%s: %s", check.Name, check.Detail)
+		switch {
+		case check.OK:
+			fmt.Println("  " + ui.Success(line))
+		case check.Blocking:
+			fmt.Println("  " + ui.Failed(line+i18n.T("preflight.blocking")))
+		default:
+			fmt.Println("  " + ui.Warning(line))
+		}
+	}
+}
+
+// Run ejecuta todas las verificaciones para instalar packages con manager
+// ("pacman", "apt", "dnf" o "flatpak")
+func Run(manager string, pkgs []string) Report {
+	var report Report
+
+	download, installed, unknown := packages.EstimateSizes(manager, pkgs)
+	needs := []spaceNeed{{Path: "/", Needed: installed}}
+	if manager != "flatpak" {
+		needs = append(needs, spaceNeed{Path: "/var/cache", Needed: download})
+	}
+	for _, fs := range groupFilesystems(needs, statSpace) {
+		report.Checks = append(report.Checks, checkDiskSpace(fs, unknown))
+	}
+
+	if check, ok := checkPower(); ok {
+		report.Checks = append(report.Checks, check)
+	}
+
+	report.Checks = append(report.Checks, checkLock(manager))
+
+	if manager != "flatpak" {
+		report.Checks = append(report.Checks, checkSudo())
+	}
+
+	report.Checks = append(report.Checks, checkMirrors(manager))
+
+	if manager == "pacman" {
+		report.Checks = append(report.Checks, checkPartialUpgrade())
+	}
+
+	return report
+}
+
+// spaceNeed es lo que una instalación ocupará en un directorio
+type spaceNeed struct {
+	Path   string
+	Needed int64
+}
+
+// filesystemSpace es el espacio de un sistema de archivos y lo que necesitan
+// las rutas que están en él
+type filesystemSpace struct {
+	Paths  []string
+	Free   int64
+	Needed int64
+	Known  bool // false si no se pudo consultar
+}
+
+// statSpaceFunc retorna el dispositivo de path y su espacio libre
+type statSpaceFunc func(path string) (device uint64, free int64, err error)
+
+func statSpace(path string) (uint64, int64, error) {
+	var st syscall.Stat_t
+	if err := syscall.Stat(path, &st); err != nil {
+		return 0, 0, err
+	}
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(path, &fs); err != nil {
+		return 0, 0, err
+	}
+	return uint64(st.Dev), int64(fs.Bavail) * int64(fs.Bsize), nil
+}
+
+// groupFilesystems suma lo que necesitan las rutas de un mismo dispositivo:
+// si / y /var/cache están en la misma partición, la descarga y la instalación
+// salen del mismo espacio libre
+func groupFilesystems(needs []spaceNeed, stat statSpaceFunc) []filesystemSpace {
+	var groups []filesystemSpace
+	byDevice := make(map[uint64]int)
+
+	for _, need := range needs {
+		device, free, err := stat(need.Path)
+		if err != nil {
+			groups = append(groups, filesystemSpace{Paths: []string{need.Path}, Needed: need.Needed})
+			continue
+		}
+		if i, ok := byDevice[device]; ok {
+			groups[i].Paths = append(groups[i].Paths, need.Path)
+			groups[i].Needed += need.Needed
+			continue
+		}
+		byDevice[device] = len(groups)
+		groups = append(groups, filesystemSpace{Paths: []string{need.Path}, Free: free, Needed: need.Needed, Known: true})
+	}
+	return groups
+}
+
+// checkDiskSpace compara el espacio libre de un sistema de archivos con el
+// tamaño estimado; unknown es cuántos paquetes no entraron en la estimación
+func checkDiskSpace(fs filesystemSpace, unknown int) Check {
+	check := Check{Name: i18n.T("preflight.space", strings.Join(fs.Paths, ", ")), Blocking: true}
+
+	if !fs.Known {
+		check.OK = true
+		check.Blocking = false
+		check.Detail = i18n.T("preflight.unverified")
+		return check
+	}
+
+	check.OK = fs.Free >= fs.Needed+minFreeSpace
+
+	if fs.Needed > 0 {
+		check.Detail = i18n.T("preflight.space_low", packages.FormatSize(fs.Free), packages.FormatSize(fs.Needed))
+	} else {
+		check.Detail = i18n.T("preflight.space_free", packages.FormatSize(fs.Free))
+	}
+	if unknown > 0 {
+		check.Detail += i18n.T("preflight.space_incomplete", unknown)
+	}
+	return check
+}
+
+// checkPower advierte si un portátil está funcionando con batería.
+// Retorna false si el equipo no tiene batería.
+func checkPower() (Check, bool) {
+	supplies, _ := filepath.Glob("/sys/class/power_supply/*")
+
+	hasBattery, onAC := false, false
+	capacity := ""
+	for _, supply := range supplies {
+		kind := readSysfs(filepath.Join(supply, "type"))
+		switch kind {
+		case "Battery":
+			hasBattery = true
+			capacity = readSysfs(filepath.Join(supply, "capacity"))
+		case "Mains", "USB":
+			if readSysfs(filepath.Join(supply, "online")) == "1" {
+				onAC = true
+			}
+		}
+	}
+
+	if !hasBattery {
+		return Check{}, false
+	}
+
+	check := Check{Name: i18n.T("preflight.power"), OK: onAC, Detail: i18n.T("preflight.on_ac")}
+	if !onAC {
+		check.Detail = i18n.T("preflight.on_battery")
+		if capacity != "" {
+			check.Detail += fmt.Sprintf(" (%s%%)", capacity)
+		}
+	}
+	return check, true
+}
+
+func readSysfs(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// checkLock verifica que ningún otro proceso tenga tomado el gestor de paquetes
+func checkLock(manager string) Check {
+	check := Check{Name: i18n.T("preflight.lock"), OK: true, Blocking: true, Detail: i18n.T("preflight.lock_free")}
+
+	var processes []string
+	switch manager {
+	case "pacman":
+		if _, err := os.Stat("/var/lib/pacman/db.lck"); err == nil {
+			check.OK = false
+			check.Detail = i18n.T("preflight.pacman_lock")
+			if !processRunning("pacman") {
+				check.Detail += i18n.T("preflight.stale_lock")
+			}
+			return check
+		}
+		processes = []string{"pacman"}
+	case "apt":
+		processes = []string{"apt", "apt-get", "dpkg", "unattended-upgr"}
+	case "dnf":
+		processes = []string{"dnf", "dnf5", "rpm"}
+	case "flatpak":
+		return check
+	}
+
+	for _, proc := range processes {
+		if processRunning(proc) {
+			check.OK = false
+			check.Detail = i18n.T("preflight.running", proc)
+			break
+		}
+	}
+	return check
+}
+
+func processRunning(name string) bool {
+	_, err := utils.RunCommandSilent("pgrep", "-x", name)
+	return err == nil
+}
+
+// checkSudo verifica que haya una herramienta de elevación y si las credenciales siguen en caché
+func checkSudo() Check {
+	check := Check{Name: i18n.T("preflight.privileges"), OK: true, Blocking: true}
+
+	if utils.IsRoot() {
+		check.Detail = i18n.T("preflight.root")
+		return check
+	}
+
+	tool := utils.PrivilegeTool()
+	if tool == "" {
+		check.OK = false
+		check.Detail = i18n.T("preflight.no_privilege_tool")
+		return check
+	}
+
+	if !utils.PrivilegesCached() {
+		check.OK = false
+		check.Blocking = false
+		check.Detail = i18n.T("preflight.will_ask", tool)
+		return check
+	}
+
+	check.Detail = i18n.T("preflight.cached", tool)
+	return check
+}
+
+// checkMirrors verifica que al menos un mirror configurado responda.
+// Los mirrors locales (file://) cuentan si el directorio existe.
+func checkMirrors(manager string) Check {
+	check := Check{Name: i18n.T("preflight.mirrors"), Blocking: true}
+
+	mirrors := configuredMirrors(manager)
+	if len(mirrors) == 0 {
+		check.OK = true
+		check.Blocking = false
+		check.Detail = i18n.T("preflight.no_mirrors")
+		return check
+	}
+
+	for _, mirror := range mirrors {
+		if mirrorReachable(mirror) {
+			check.OK = true
+			check.Detail = i18n.T("preflight.mirror_ok", mirrorHost(mirror))
+			return check
+		}
+	}
+
+	check.Detail = i18n.T("preflight.mirrors_down", len(mirrors))
+	return check
+}
+
+// maxMirrors es la cantidad de mirrors que se prueban como máximo
+const maxMirrors = 3
+
+// configuredMirrors lee los primeros mirrors de la configuración del gestor
+func configuredMirrors(manager string) []string {
+	var mirrors []string
+
+	add := func(raw string) {
+		if len(mirrors) < maxMirrors && raw != "" {
+			mirrors = append(mirrors, raw)
+		}
+	}
+
+	switch manager {
+	case "pacman":
+		scanFile("/etc/pacman.d/mirrorlist", func(line string) {
+			if key, value, ok := strings.Cut(line, "="); ok && strings.TrimSpace(key) == "Server" {
+				add(strings.TrimSpace(value))
+			}
+		})
+	case "apt":
+		sources := []string{"/etc/apt/sources.list"}
+		extra, _ := filepath.Glob("/etc/apt/sources.list.d/*")
+		sources = append(sources, extra...)
+		for _, source := range sources {
+			scanFile(source, func(line string) {
+				for _, field := range strings.Fields(line) {
+					if strings.Contains(field, "://") {
+						add(field)
+						return
+					}
+				}
+			})
+		}
+	case "dnf":
+		add("https://mirrors.fedoraproject.org")
+	case "flatpak":
+		add("https://dl.flathub.org")
+	}
+
+	return mirrors
+}
+
+// scanFile llama a fn con cada línea no comentada de un archivo
+func scanFile(path string, fn func(line string)) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			fn(line)
+		}
+	}
+}
+
+func mirrorHost(mirror string) string {
+	if u, err := url.Parse(mirror); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return mirror
+}
+
+// mirrorReachable intenta abrir una conexión TCP con el mirror
+func mirrorReachable(mirror string) bool {
+	u, err := url.Parse(mirror)
+	if err != nil {
+		return false
+	}
+
+	if u.Scheme == "file" {
+		_, err := os.Stat(u.Path)
+		return err == nil
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(u.Hostname(), port), mirrorTimeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// checkPartialUpgrade advierte si la base de datos de pacman tiene actualizaciones
+// sin aplicar: instalar sin -Syu dejaría el sistema en una actualización parcial
+func checkPartialUpgrade() Check {
+	check := Check{Name: i18n.T("preflight.updates"), OK: true, Detail: i18n.T("preflight.up_to_date")}
+
+	output, err := utils.RunCommandSilent("pacman", "-Qu")
+	if err != nil || strings.TrimSpace(output) == "" {
+		return check
+	}
+
+	pending := len(strings.Split(strings.TrimSpace(output), "\n"))
+	check.OK = false
+	check.Detail = i18n.T("preflight.outdated", pending)
+	return check
+}
@@ -0,0 +1,132 @@
+package preflight
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// fakeStat simula statSpace con un dispositivo y espacio libre por ruta
+func fakeStat(devices map[string]uint64, free map[uint64]int64) statSpaceFunc {
+	return func(path string) (uint64, int64, error) {
+		device, ok := devices[path]
+		if !ok {
+			return 0, 0, errors.New("no existe")
+		}
+		return device, free[device], nil
+	}
+}
+
+func TestGroupFilesystems(t *testing.T) {
+	needs := []spaceNeed{{Path: "/", Needed: 300}, {Path: "/var/cache", Needed: 100}}
+
+	tests := []struct {
+		name    string
+		devices map[string]uint64
+		want    []filesystemSpace
+	}{
+		{
+			name:    "misma partición: se suma lo requerido",
+			devices: map[string]uint64{"/": 1, "/var/cache": 1},
+			want:    []filesystemSpace{{Paths: []string{"/", "/var/cache"}, Free: 1000, Needed: 400, Known: true}},
+		},
+		{
+			name:    "particiones distintas",
+			devices: map[string]uint64{"/": 1, "/var/cache": 2},
+			want: []filesystemSpace{
+				{Paths: []string{"/"}, Free: 1000, Needed: 300, Known: true},
+				{Paths: []string{"/var/cache"}, Free: 50, Needed: 100, Known: true},
+			},
+		},
+		{
+			name:    "una ruta que no se puede consultar",
+			devices: map[string]uint64{"/": 1},
+			want: []filesystemSpace{
+				{Paths: []string{"/"}, Free: 1000, Needed: 300, Known: true},
+				{Paths: []string{"/var/cache"}, Needed: 100},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := groupFilesystems(needs, fakeStat(tt.devices, map[uint64]int64{1: 1000, 2: 50}))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("grupos %+v, se esperaba %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDiskSpace(t *testing.T) {
+	const mb = 1 << 20
+
+	tests := []struct {
+		name         string
+		fs           filesystemSpace
+		wantOK       bool
+		wantBlocking bool
+	}{
+		{
+			name:         "alcanza con el margen",
+			fs:           filesystemSpace{Paths: []string{"/"}, Free: 2048 * mb, Needed: 1024 * mb, Known: true},
+			wantOK:       true,
+			wantBlocking: true,
+		},
+		{
+			name:         "no alcanza el margen",
+			fs:           filesystemSpace{Paths: []string{"/"}, Free: 1200 * mb, Needed: 1024 * mb, Known: true},
+			wantOK:       false,
+			wantBlocking: true,
+		},
+		{
+			name:         "sin datos no bloquea",
+			fs:           filesystemSpace{Paths: []string{"/"}, Needed: 1024 * mb},
+			wantOK:       true,
+			wantBlocking: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := checkDiskSpace(tt.fs, 0)
+			if check.OK != tt.wantOK || check.Blocking != tt.wantBlocking {
+				t.Errorf("OK=%v Blocking=%v, se esperaba OK=%v Blocking=%v", check.OK, check.Blocking, tt.wantOK, tt.wantBlocking)
+			}
+		})
+	}
+
+	incomplete := checkDiskSpace(filesystemSpace{Paths: []string{"/"}, Free: 2048 * mb, Known: true}, 3)
+	plain := checkDiskSpace(filesystemSpace{Paths: []string{"/"}, Free: 2048 * mb, Known: true}, 0)
+	if incomplete.Detail == plain.Detail {
+		t.Errorf("una estimación incompleta debería avisarlo: %q", incomplete.Detail)
+	}
+}
+
+func TestReportBlocked(t *testing.T) {
+	report := Report{Checks: []Check{
+		{Name: "a", OK: true, Blocking: true},
+		{Name: "b", OK: false, Blocking: false},
+	}}
+	if report.Blocked() {
+		t.Error("un aviso no bloqueante no debería bloquear")
+	}
+
+	report.Checks = append(report.Checks, Check{Name: "c", OK: false, Blocking: true})
+	if !report.Blocked() {
+		t.Error("una verificación bloqueante fallida debería bloquear")
+	}
+}
+
+func TestMirrorHost(t *testing.T) {
+	tests := map[string]string{
+		"https://mirror.example.org/archlinux/$repo/os/$arch": "mirror.example.org",
+		"http://deb.debian.org/debian":                        "deb.debian.org",
+		"file:///srv/repo":                                    "file:///srv/repo",
+	}
+	for mirror, want := range tests {
+		if got := mirrorHost(mirror); got != want {
+			t.Errorf("mirrorHost(%q) = %q, se esperaba %q", mirror, got, want)
+		}
+	}
+}
@@ -1,21 +1,65 @@
 package ui
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/huh"
+
+	"orgmos/internal/i18n"
 )
 
-// NewForm crea un formulario con soporte para salir usando Esc.
-func NewForm(groups ...*huh.Group) *huh.Form {
+// Form es un formulario de huh que se adapta a la terminal: en modo plano
+// pregunta línea por línea y sin terminal no se muestra.
+type Form struct {
+	*huh.Form
+}
+
+// NewForm crea un formulario con los colores del tema y soporte para salir usando Esc.
+func NewForm(groups ...*huh.Group) *Form {
 	form := huh.NewForm(groups...)
 
 	keyMap := huh.NewDefaultKeyMap()
 	keyMap.Quit = key.NewBinding(
 		key.WithKeys("esc", "ctrl+c"),
-		key.WithHelp("esc", "salir"),
+		key.WithHelp("esc", i18n.T("ui.quit")),
 	)
 
 	form.WithKeyMap(keyMap)
-	return form
+	form.WithTheme(formTheme())
+	form.WithAccessible(Plain())
+	return &Form{Form: form}
+}
+
+// Run muestra el formulario. Sin terminal avisa y retorna ErrNoTerminal.
+func (f *Form) Run() error {
+	if !Interactive() {
+		fmt.Println(Warning(i18n.T("ui.needs_terminal")))
+		return ErrNoTerminal
+	}
+	return f.Form.Run()
+}
+
+// RunOrDefault muestra el formulario. Sin terminal, con --yes no lo muestra y
+// los campos conservan su valor inicial; sin --yes se comporta como Run.
+func (f *Form) RunOrDefault() error {
+	if !Interactive() && AssumeYes() {
+		return nil
+	}
+	return f.Run()
+}
+
+// RunConfirm muestra un formulario de confirmación guardada en value. Con --yes
+// no se muestra y se da por aceptada; sin terminal se rechaza y se indica que
+// puede aceptarse con --yes.
+func (f *Form) RunConfirm(value *bool) error {
+	if AssumeYes() {
+		*value = true
+		return nil
+	}
+	if !Interactive() {
+		*value = false
+	}
+	return f.Run()
 }
 
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"orgmos/internal/i18n"
+)
+
+// Modos de salida (--output)
+const (
+	OutputText = "text" // Mensajes con estilos para la terminal
+	OutputJSON = "json" // Documento JSON en stdout y eventos NDJSON en stderr
+)
+
+var (
+	outputMode = OutputText
+
+	eventsMu    sync.Mutex
+	errorEvents int
+)
+
+// Event es una línea NDJSON que se escribe en stderr en modo JSON por cada
+// mensaje de progreso (Info, Success, Warning, Error o Title)
+type Event struct {
+	Type    string    `json:"type"`  // Siempre "event"
+	Level   string    `json:"level"` // "title", "info", "success", "warning" o "error"
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// SetOutputMode cambia el modo de salida. En modo JSON los mensajes de los
+// helpers se emiten como eventos en stderr; los comandos que admiten JSON
+// escriben su texto en TextOut, así stdout contiene solo el documento.
+func SetOutputMode(mode string) error {
+	switch mode {
+	case OutputText, OutputJSON:
+	default:
+		return fmt.Errorf(i18n.T("ui.unknown_output"), mode)
+	}
+
+	outputMode = mode
+	return nil
+}
+
+// JSONOutput indica si la salida es JSON
+func JSONOutput() bool {
+	return outputMode == OutputJSON
+}
+
+// TextOut retorna dónde escribir el texto para la terminal (tablas, líneas
+// sueltas, mensajes de los helpers): stdout en modo texto y nada en modo JSON
+func TextOut() io.Writer {
+	if JSONOutput() {
+		return io.Discard
+	}
+	return os.Stdout
+}
+
+// PrintJSON escribe un documento JSON en w
+func PrintJSON(w io.Writer, doc any) error {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// ErrorEvents retorna cuántos mensajes de error se mostraron con Error
+func ErrorEvents() int {
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+	return errorEvents
+}
+
+// countError registra un mensaje de error, en cualquier modo de salida
+func countError() {
+	eventsMu.Lock()
+	errorEvents++
+	eventsMu.Unlock()
+}
+
+// emitEvent escribe un evento NDJSON en stderr
+func emitEvent(level string, message string) {
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+
+	data, err := json.Marshal(Event{Type: "event", Level: level, Message: message, Time: time.Now()})
+	if err != nil {
+		return
+	}
+	os.Stderr.Write(append(data, '\n'))
+}
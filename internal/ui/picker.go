@@ -0,0 +1,493 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+
+	"orgmos/internal/i18n"
+)
+
+// PickerItem es un elemento seleccionable del picker agrupado
+type PickerItem struct {
+	Group       string // Sección a la que pertenece (# === nombre ===)
+	Value       string // Valor retornado al seleccionarlo
+	Label       string
+	Description string
+	Detail      string // Información corta adicional: origen, tamaño, etc.
+	Selected    bool
+}
+
+// pickerRow es una fila visible: un encabezado de grupo o un elemento
+type pickerRow struct {
+	group string
+	item  int // -1 si la fila es un encabezado
+}
+
+// pickerModel es el modelo bubbletea del picker agrupado
+type pickerModel struct {
+	title       string
+	description string
+	items       []PickerItem
+
+	filter    string
+	filtering bool
+	cursor    int // Índice dentro de visibleItems()
+	offset    int // Primera fila mostrada
+	height    int
+	width     int
+
+	done    bool
+	aborted bool
+}
+
+// RunPicker muestra una lista multi-selección agrupada por secciones, con filtro
+// difuso, selección por grupo y descripción de cada elemento.
+// Retorna huh.ErrUserAborted si el usuario cancela.
+//
+// En modo plano pregunta línea por línea. Sin terminal, con --yes retorna los
+// elementos marcados de antemano; sin --yes avisa y retorna ErrNoTerminal.
+func RunPicker(title string, description string, items []PickerItem) ([]string, error) {
+	if !Interactive() {
+		if AssumeYes() {
+			return selectedValues(items), nil
+		}
+		fmt.Println(Warning(i18n.T("ui.needs_terminal")))
+		return nil, ErrNoTerminal
+	}
+	if Plain() {
+		return runPlainPicker(title, description, items)
+	}
+
+	model := &pickerModel{
+		title:       title,
+		description: description,
+		items:       items,
+		height:      20,
+		width:       80,
+	}
+
+	result, err := tea.NewProgram(model).Run()
+	if err != nil {
+		return nil, err
+	}
+
+	final := result.(*pickerModel)
+	if final.aborted {
+		return nil, huh.ErrUserAborted
+	}
+	return selectedValues(final.items), nil
+}
+
+// selectedValues retorna los valores de los elementos marcados
+func selectedValues(items []PickerItem) []string {
+	var selected []string
+	for _, item := range items {
+		if item.Selected {
+			selected = append(selected, item.Value)
+		}
+	}
+	return selected
+}
+
+// runPlainPicker es el picker del modo plano: lista los elementos numerados y
+// pregunta cuáles seleccionar. Enter acepta los marcados de antemano.
+func runPlainPicker(title string, description string, items []PickerItem) ([]string, error) {
+	fmt.Println(title)
+	if description != "" {
+		fmt.Println(description)
+	}
+
+	lastGroup := "\x00"
+	for i, item := range items {
+		if item.Group != lastGroup {
+			fmt.Printf("\n%s:\n", item.Group)
+			lastGroup = item.Group
+		}
+
+		mark := " "
+		if item.Selected {
+			mark = "x"
+		}
+		line := fmt.Sprintf("  %d. [%s] %s", i+1, mark, item.Label)
+		if item.Detail != "" {
+			line += ", " + item.Detail
+		}
+		if item.Description != "" {
+			line += ", " + item.Description
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print(i18n.T("ui.picker_prompt", len(selectedValues(items))))
+		if !scanner.Scan() {
+			fmt.Println()
+			return nil, huh.ErrUserAborted
+		}
+
+		chosen, err := parseSelection(scanner.Text(), len(items))
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		if chosen == nil {
+			return selectedValues(items), nil
+		}
+
+		var selected []string
+		for _, idx := range chosen {
+			selected = append(selected, items[idx].Value)
+		}
+		return selected, nil
+	}
+}
+
+// parseSelection interpreta la respuesta del picker plano y retorna los índices
+// elegidos, o nil si la respuesta está vacía
+func parseSelection(input string, count int) ([]int, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	switch input {
+	case "":
+		return nil, nil
+	case "todos", "all":
+		all := make([]int, count)
+		for i := range all {
+			all[i] = i
+		}
+		return all, nil
+	case "ninguno", "none":
+		return []int{}, nil
+	}
+
+	seen := make(map[int]bool)
+	var chosen []int
+	for _, field := range strings.FieldsFunc(input, func(r rune) bool { return r == ' ' || r == ',' }) {
+		from, to, isRange := strings.Cut(field, "-")
+		if !isRange {
+			to = from
+		}
+
+		start, err1 := strconv.Atoi(from)
+		end, err2 := strconv.Atoi(to)
+		if err1 != nil || err2 != nil || start < 1 || end > count || start > end {
+			return nil, fmt.Errorf(i18n.T("ui.picker_invalid"), field, count)
+		}
+
+		for n := start; n <= end; n++ {
+			if !seen[n-1] {
+				seen[n-1] = true
+				chosen = append(chosen, n-1)
+			}
+		}
+	}
+	return chosen, nil
+}
+
+func (m *pickerModel) Init() tea.Cmd {
+	return nil
+}
+
+// fuzzyMatch verifica si todas las letras de pattern aparecen en orden dentro de text
+func fuzzyMatch(text string, pattern string) bool {
+	text = strings.ToLower(text)
+	pattern = strings.ToLower(pattern)
+
+	idx := 0
+	for _, r := range pattern {
+		pos := strings.IndexRune(text[idx:], r)
+		if pos < 0 {
+			return false
+		}
+		idx += pos + len(string(r))
+	}
+	return true
+}
+
+// visibleItems retorna los índices de los elementos que pasan el filtro
+func (m *pickerModel) visibleItems() []int {
+	var visible []int
+	for i, item := range m.items {
+		if m.filter == "" || fuzzyMatch(item.Label+" "+item.Description, m.filter) {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
+
+// rows construye las filas visibles intercalando los encabezados de grupo
+func (m *pickerModel) rows() []pickerRow {
+	var rows []pickerRow
+	lastGroup := "\x00"
+	for _, idx := range m.visibleItems() {
+		group := m.items[idx].Group
+		if group != lastGroup {
+			rows = append(rows, pickerRow{group: group, item: -1})
+			lastGroup = group
+		}
+		rows = append(rows, pickerRow{group: group, item: idx})
+	}
+	return rows
+}
+
+// toggleGroup selecciona o deselecciona todos los elementos visibles de un grupo
+func (m *pickerModel) toggleGroup(group string) {
+	visible := m.visibleItems()
+
+	allSelected := true
+	for _, idx := range visible {
+		if m.items[idx].Group == group && !m.items[idx].Selected {
+			allSelected = false
+			break
+		}
+	}
+
+	for _, idx := range visible {
+		if m.items[idx].Group == group {
+			m.items[idx].Selected = !allSelected
+		}
+	}
+}
+
+// toggleAll selecciona o deselecciona todos los elementos visibles
+func (m *pickerModel) toggleAll() {
+	visible := m.visibleItems()
+
+	allSelected := true
+	for _, idx := range visible {
+		if !m.items[idx].Selected {
+			allSelected = false
+			break
+		}
+	}
+
+	for _, idx := range visible {
+		m.items[idx].Selected = !allSelected
+	}
+}
+
+func (m *pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		// Reservar espacio para título, descripción, filtro y ayuda
+		m.height = max(msg.Height-7, 3)
+
+	case tea.KeyMsg:
+		var cmd tea.Cmd
+		if m.filtering {
+			cmd = m.updateFilter(msg)
+		} else {
+			cmd = m.updateNormal(msg)
+		}
+		m.scroll()
+		return m, cmd
+	}
+
+	m.scroll()
+	return m, nil
+}
+
+// cursorRow retorna la fila que contiene el elemento bajo el cursor
+func (m *pickerModel) cursorRow(rows []pickerRow) int {
+	visible := m.visibleItems()
+	if len(visible) == 0 {
+		return 0
+	}
+	for i, row := range rows {
+		if row.item == visible[m.cursor] {
+			return i
+		}
+	}
+	return 0
+}
+
+// scroll mantiene el cursor (y su encabezado) dentro de la ventana visible
+func (m *pickerModel) scroll() {
+	rows := m.rows()
+	cursorRow := m.cursorRow(rows)
+
+	if cursorRow < m.offset {
+		m.offset = cursorRow
+		if m.offset > 0 && rows[m.offset-1].item == -1 {
+			m.offset--
+		}
+	}
+	if cursorRow >= m.offset+m.height {
+		m.offset = cursorRow - m.height + 1
+	}
+}
+
+func (m *pickerModel) updateFilter(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.filter = ""
+		m.filtering = false
+	case tea.KeyEnter:
+		m.filtering = false
+	case tea.KeyBackspace:
+		if m.filter != "" {
+			runes := []rune(m.filter)
+			m.filter = string(runes[:len(runes)-1])
+		}
+	case tea.KeyCtrlC:
+		m.aborted = true
+		return tea.Quit
+	case tea.KeyRunes, tea.KeySpace:
+		m.filter += string(msg.Runes)
+	}
+
+	m.cursor = 0
+	m.offset = 0
+	return nil
+}
+
+func (m *pickerModel) updateNormal(msg tea.KeyMsg) tea.Cmd {
+	visible := m.visibleItems()
+
+	switch msg.String() {
+	case "ctrl+c", "esc", "q":
+		m.aborted = true
+		return tea.Quit
+	case "enter":
+		m.done = true
+		return tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(visible)-1 {
+			m.cursor++
+		}
+	case "pgup":
+		m.cursor = max(m.cursor-m.height, 0)
+	case "pgdown":
+		m.cursor = max(min(m.cursor+m.height, len(visible)-1), 0)
+	case "home":
+		m.cursor = 0
+	case "end":
+		m.cursor = max(len(visible)-1, 0)
+	case " ", "x":
+		if len(visible) > 0 {
+			idx := visible[m.cursor]
+			m.items[idx].Selected = !m.items[idx].Selected
+		}
+	case "g":
+		if len(visible) > 0 {
+			m.toggleGroup(m.items[visible[m.cursor]].Group)
+		}
+	case "a":
+		m.toggleAll()
+	case "/":
+		m.filtering = true
+	}
+
+	return nil
+}
+
+func (m *pickerModel) View() string {
+	if m.done || m.aborted {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(HighlightStyle.Render(m.title) + "\n")
+	if m.description != "" {
+		b.WriteString(DimStyle.Render(m.description) + "\n")
+	}
+
+	selectedCount := 0
+	for _, item := range m.items {
+		if item.Selected {
+			selectedCount++
+		}
+	}
+
+	filterLine := i18n.T("ui.picker_selected", selectedCount, len(m.items))
+	if m.filtering || m.filter != "" {
+		cursorMark := ""
+		if m.filtering {
+			cursorMark = "█"
+		}
+		filterLine = i18n.T("ui.picker_filter", m.filter, cursorMark, filterLine)
+	}
+	b.WriteString(DimStyle.Render(filterLine) + "\n\n")
+
+	rows := m.rows()
+	cursorRow := m.cursorRow(rows)
+
+	end := min(m.offset+m.height, len(rows))
+	for i := m.offset; i < end; i++ {
+		row := rows[i]
+		if row.item == -1 {
+			b.WriteString(m.renderHeader(row.group) + "\n")
+			continue
+		}
+		b.WriteString(m.renderItem(row.item, i == cursorRow) + "\n")
+	}
+
+	if len(rows) == 0 {
+		b.WriteString(DimStyle.Render(i18n.T("ui.picker_no_matches")) + "\n")
+	}
+
+	b.WriteString("\n" + DimStyle.Render(i18n.T("ui.picker_help")))
+	return b.String()
+}
+
+func (m *pickerModel) renderHeader(group string) string {
+	total, selected := 0, 0
+	for _, item := range m.items {
+		if item.Group == group {
+			total++
+			if item.Selected {
+				selected++
+			}
+		}
+	}
+	return TitleStyle.UnsetMarginBottom().Render(fmt.Sprintf("%s (%d/%d)", group, selected, total))
+}
+
+func (m *pickerModel) renderItem(idx int, focused bool) string {
+	item := m.items[idx]
+
+	check := "[ ]"
+	if item.Selected {
+		check = SuccessStyle.Render("[✓]")
+	}
+
+	pointer := "  "
+	label := item.Label
+	if focused {
+		pointer = HighlightStyle.Render("> ")
+		label = HighlightStyle.Render(label)
+	}
+
+	line := fmt.Sprintf("%s%s %s", pointer, check, label)
+
+	var extra []string
+	if item.Detail != "" {
+		extra = append(extra, item.Detail)
+	}
+	if item.Description != "" {
+		extra = append(extra, item.Description)
+	}
+	if len(extra) > 0 {
+		info := strings.Join(extra, " · ")
+		// Recortar para no desbordar el ancho de la terminal
+		available := m.width - len(item.Label) - 10
+		if available > 10 && len([]rune(info)) > available {
+			info = string([]rune(info)[:available-1]) + "…"
+		}
+		line += "  " + DimStyle.Render(info)
+	}
+
+	return line
+}
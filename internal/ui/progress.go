@@ -0,0 +1,313 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"orgmos/internal/i18n"
+)
+
+// progressOutputLines es cuántas líneas de salida muestra el panel desplegado
+const progressOutputLines = 12
+
+// ProgressPhase es una etapa de una instalación: descarga, compilación o instalación
+type ProgressPhase struct {
+	Name    string
+	Current int     // Unidades terminadas, ej: paquetes descargados
+	Total   int     // Unidades de la etapa, 0 si no se conocen
+	Percent float64 // Avance de la unidad en curso, o de toda la etapa sin Total (0 a 1)
+	Started bool
+	Done    bool
+}
+
+// Fraction retorna el avance de la etapa, de 0 a 1
+func (p ProgressPhase) Fraction() float64 {
+	switch {
+	case p.Done:
+		return 1
+	case p.Total > 0:
+		return min((float64(p.Current)+p.Percent)/float64(p.Total), 1)
+	default:
+		return min(p.Percent, 1)
+	}
+}
+
+// Progress es el estado de una instalación según la salida del gestor
+type Progress struct {
+	Phases  []ProgressPhase
+	Active  int    // Etapa en curso, -1 si todavía no empezó ninguna
+	Package string // Paquete en curso
+}
+
+// Fraction retorna el avance total: el promedio del avance de las etapas. Las
+// etapas anteriores a la activa cuentan como completas aunque no hayan llegado
+// a empezar (ej: no hubo descargas porque todo estaba en caché).
+func (p Progress) Fraction() float64 {
+	if len(p.Phases) == 0 {
+		return 0
+	}
+	var total float64
+	for i, phase := range p.Phases {
+		if i < p.Active {
+			total++
+			continue
+		}
+		total += phase.Fraction()
+	}
+	return total / float64(len(p.Phases))
+}
+
+// ProgressTracker interpreta la salida de un gestor de paquetes
+type ProgressTracker interface {
+	// Feed procesa una línea (partial si aún no terminó) y retorna false si
+	// es una línea de estado que no debe aparecer en la salida
+	Feed(line string, partial bool) bool
+	Progress() Progress
+}
+
+// ProgressAvailable indica si se puede mostrar la vista de progreso: hace falta
+// una terminal interactiva, fuera del modo plano, la salida JSON y el panel
+func ProgressAvailable() bool {
+	return interactive && !plainMode && !JSONOutput()
+}
+
+// ProgressView muestra el avance de un comando con una barra, la etapa y el
+// paquete en curso; la salida completa queda en un panel plegable
+type ProgressView struct {
+	model   *progressModel
+	program *tea.Program
+
+	mu      sync.Mutex
+	running bool
+}
+
+// NewProgressView crea la vista con el título y el intérprete de la salida
+func NewProgressView(title string, tracker ProgressTracker) *ProgressView {
+	return &ProgressView{
+		model: &progressModel{
+			title:   title,
+			tracker: tracker,
+			bar: progress.New(
+				progress.WithSolidFill(string(Blue)),
+				progress.WithoutPercentage(),
+				progress.WithColorProfile(lipgloss.ColorProfile()),
+			),
+			width: 80,
+		},
+	}
+}
+
+// Run ejecuta action mostrando el avance. Lo que action escriba en out, en
+// especial la salida de sus comandos, pasa por el intérprete y queda en el panel
+// de salida (se despliega con "o"). La terminal la usa la vista: los comandos no
+// deben leer de ella ni escribir directamente.
+func (v *ProgressView) Run(action func(out io.Writer) error) error {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return action(nil)
+	}
+
+	v.model.started = time.Now()
+	v.program = tea.NewProgram(v.model, tea.WithoutSignalHandler())
+
+	streamed := make(chan struct{})
+	go func() {
+		StreamLines(reader, func(line string, partial bool) {
+			v.program.Send(progressLineMsg{line: line, partial: partial})
+		})
+		close(streamed)
+	}()
+
+	var actionErr error
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		actionErr = action(writer)
+
+		writer.Close()
+		<-streamed
+		reader.Close()
+		v.program.Send(progressDoneMsg{err: actionErr})
+	}()
+
+	v.mu.Lock()
+	v.running = true
+	v.mu.Unlock()
+
+	v.program.Run()
+
+	v.mu.Lock()
+	v.running = false
+	v.mu.Unlock()
+
+	<-finished
+	return actionErr
+}
+
+// Stop cierra la vista y restaura la terminal; se usa al cancelar con Ctrl+C
+func (v *ProgressView) Stop() {
+	v.mu.Lock()
+	running := v.running
+	v.mu.Unlock()
+
+	if running {
+		v.program.Kill()
+		v.program.Wait()
+	}
+}
+
+// Mensajes de la vista de progreso
+type (
+	progressLineMsg struct {
+		line    string
+		partial bool
+	}
+	progressDoneMsg struct{ err error }
+	progressTickMsg struct{}
+)
+
+// progressModel es el modelo bubbletea de ProgressView
+type progressModel struct {
+	title   string
+	tracker ProgressTracker
+	bar     progress.Model
+	started time.Time
+
+	lines      []string
+	partial    bool
+	showOutput bool
+
+	width int
+	done  bool
+	err   error
+}
+
+func progressTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return progressTickMsg{}
+	})
+}
+
+func (m *progressModel) Init() tea.Cmd {
+	return progressTick()
+}
+
+func (m *progressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+
+	case progressTickMsg:
+		if !m.done {
+			return m, progressTick()
+		}
+
+	case progressLineMsg:
+		if m.tracker.Feed(msg.line, msg.partial) {
+			m.appendLine(msg.line, msg.partial)
+		}
+
+	case progressDoneMsg:
+		m.done = true
+		m.err = msg.err
+		return m, tea.Quit
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "o":
+			m.showOutput = !m.showOutput
+		case "ctrl+c":
+			// Como la terminal está en modo raw, se envía SIGINT a todo el grupo
+			// de procesos igual que lo haría Ctrl+C fuera de la vista
+			syscall.Kill(0, syscall.SIGINT)
+		}
+	}
+	return m, nil
+}
+
+// appendLine agrega una línea a la salida; si la última era parcial la reemplaza
+func (m *progressModel) appendLine(line string, partial bool) {
+	if m.partial && len(m.lines) > 0 {
+		m.lines[len(m.lines)-1] = line
+	} else {
+		m.lines = append(m.lines, line)
+	}
+	m.partial = partial
+
+	if len(m.lines) > progressOutputLines {
+		m.lines = m.lines[len(m.lines)-progressOutputLines:]
+	}
+}
+
+func (m *progressModel) View() string {
+	elapsed := time.Since(m.started).Round(time.Second)
+	if m.done {
+		if m.err != nil {
+			return Warning(i18n.T("ui.progress_failed", m.title, elapsed)) + "\n"
+		}
+		return Success(i18n.T("ui.progress_done", m.title, elapsed)) + "\n"
+	}
+
+	state := m.tracker.Progress()
+	fraction := state.Fraction()
+	width := max(m.width, 20)
+	m.bar.Width = max(min(width-24, 50), 10)
+
+	timing := elapsed.String()
+	if fraction >= 0.02 && fraction < 1 {
+		remaining := time.Duration(float64(time.Since(m.started)) * (1 - fraction) / fraction).Round(time.Second)
+		timing = i18n.T("ui.progress_eta", elapsed, remaining)
+	}
+
+	var b strings.Builder
+	b.WriteString(HighlightStyle.Render(m.title) + "\n")
+	b.WriteString(fmt.Sprintf("%s %3.0f%%  %s\n", m.bar.ViewAs(fraction), fraction*100, DimStyle.Render(timing)))
+
+	for i, phase := range state.Phases {
+		var count string
+		switch {
+		case phase.Done && phase.Total > 0:
+			count = fmt.Sprintf("%d/%d", phase.Total, phase.Total)
+		case phase.Total > 0:
+			count = fmt.Sprintf("%d/%d", min(phase.Current, phase.Total), phase.Total)
+		case phase.Started:
+			count = fmt.Sprintf("%.0f%%", phase.Fraction()*100)
+		}
+
+		line := fmt.Sprintf("%-14s %s", phase.Name, count)
+		switch {
+		case phase.Done:
+			b.WriteString(SuccessStyle.Render("  ✓ "+line) + "\n")
+		case i == state.Active:
+			b.WriteString(InfoStyle.Render("  ▶ "+line) + "\n")
+		default:
+			b.WriteString(DimStyle.Render("  · "+line) + "\n")
+		}
+	}
+
+	if state.Package != "" {
+		b.WriteString(i18n.T("ui.progress_package", HighlightStyle.Render(state.Package)) + "\n")
+	}
+
+	if m.showOutput {
+		b.WriteString(DimStyle.Render(i18n.T("ui.progress_hide")) + "\n")
+		line := lipgloss.NewStyle().MaxWidth(width - 2)
+		for _, l := range m.lines {
+			b.WriteString(DimStyle.Render("│ ") + line.Render(l) + "\n")
+		}
+	} else {
+		b.WriteString(DimStyle.Render(i18n.T("ui.progress_show")) + "\n")
+	}
+
+	// Las líneas largas se cortan: si la terminal las partiera no se podrían redibujar
+	return lipgloss.NewStyle().MaxWidth(width).Render(b.String())
+}
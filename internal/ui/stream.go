@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// controlSeqRe encuentra las secuencias de escape que no son de color (mover el
+// cursor, borrar la línea, títulos de ventana) y que romperían una vista
+var controlSeqRe = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-ln-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[()][0-9A-Za-z]`)
+
+// StreamLines lee la salida de un comando y llama a fn por cada línea, ya sin
+// secuencias de control. Un \r sin \n (barras de progreso) reemplaza la línea
+// en curso; lo que queda sin terminar se pasa con partial para verlo en vivo.
+func StreamLines(r io.Reader, fn func(line string, partial bool)) {
+	reader := bufio.NewReader(r)
+	var line []byte
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			if len(line) > 0 {
+				fn(CleanLine(string(line)), false)
+			}
+			return
+		}
+
+		switch b {
+		case '\n':
+			fn(CleanLine(string(line)), false)
+			line = line[:0]
+			continue
+		case '\r':
+			if next, err := reader.Peek(1); err == nil && next[0] == '\n' {
+				continue
+			}
+			if len(line) > 0 {
+				fn(CleanLine(string(line)), true)
+			}
+			line = line[:0]
+			continue
+		}
+
+		line = append(line, b)
+		if reader.Buffered() == 0 {
+			fn(CleanLine(string(line)), true)
+		}
+	}
+}
+
+// CleanLine quita las secuencias de control de una línea, conservando los colores
+func CleanLine(line string) string {
+	text := controlSeqRe.ReplaceAllString(line, "")
+	text = strings.ReplaceAll(text, "\t", "    ")
+	if strings.Contains(text, "\x1b[") {
+		text += "\x1b[0m"
+	}
+	return text
+}
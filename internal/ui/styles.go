@@ -1,8 +1,12 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
 
-// Colores principales del sistema
+	"orgmos/internal/i18n"
+)
+
+// Colores principales del sistema; SetPalette los cambia según el tema
 var (
 	Blue    = lipgloss.Color("#0066CC") // Mensajes informativos
 	SkyBlue = lipgloss.Color("#87CEEB") // Destacados
@@ -42,30 +46,80 @@ var (
 
 // Funciones de ayuda para imprimir mensajes
 func Title(text string) string {
+	if JSONOutput() {
+		emitEvent("title", text)
+		return ""
+	}
+	if plainMode {
+		return text + "\n"
+	}
 	return TitleStyle.Render(text)
 }
 
 func Info(text string) string {
-	return InfoStyle.Render("→ " + text)
+	if JSONOutput() {
+		emitEvent("info", text)
+		return ""
+	}
+	return InfoStyle.Render(glyph("→ ", "") + text)
 }
 
 func Success(text string) string {
-	return SuccessStyle.Render("✓ " + text)
+	if JSONOutput() {
+		emitEvent("success", text)
+		return ""
+	}
+	return SuccessStyle.Render(glyph("✓ ", "") + text)
 }
 
 func Warning(text string) string {
-	return WarningStyle.Render("⚠ " + text)
+	if JSONOutput() {
+		emitEvent("warning", text)
+		return ""
+	}
+	return WarningStyle.Render(glyph("⚠ ", i18n.T("ui.warning_prefix")) + text)
 }
 
 func Error(text string) string {
-	return ErrorStyle.Render("✗ " + text)
+	countError()
+	if JSONOutput() {
+		emitEvent("error", text)
+		return ""
+	}
+	return ErrorStyle.Render(glyph("✗ ", i18n.T("ui.error_prefix")) + text)
 }
 
+// Failed da a text el formato de Error sin contarlo como un error de esta
+// corrida, ej: un comando fallido de un log anterior. En modo JSON retorna el
+// texto sin estilos, como Highlight.
+func Failed(text string) string {
+	if JSONOutput() {
+		return text
+	}
+	return ErrorStyle.Render(glyph("✗ ", i18n.T("ui.error_prefix")) + text)
+}
+
+// Highlight y Dim se usan también dentro de otros mensajes, así que en modo
+// JSON retornan el texto sin estilos en lugar de emitir un evento
 func Highlight(text string) string {
+	if JSONOutput() {
+		return text
+	}
 	return HighlightStyle.Render(text)
 }
 
 func Dim(text string) string {
+	if JSONOutput() {
+		return text
+	}
 	return DimStyle.Render(text)
 }
 
+// glyph retorna el símbolo que precede a un mensaje, o una palabra en modo
+// plano para que se entienda sin colores y con lectores de pantalla
+func glyph(symbol string, word string) string {
+	if plainMode {
+		return word
+	}
+	return symbol
+}
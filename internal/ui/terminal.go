@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/huh/spinner"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
+
+	"orgmos/internal/i18n"
+)
+
+var (
+	// plainMode desactiva colores, símbolos, animaciones y formularios a pantalla completa
+	plainMode bool
+
+	// interactive indica si stdin es una terminal donde se puede preguntar
+	interactive = true
+
+	// assumeYes da por aceptadas las confirmaciones (solo con --yes)
+	assumeYes bool
+)
+
+// ErrNoTerminal se retorna al intentar mostrar un formulario sin terminal
+var ErrNoTerminal error = noTerminalError{}
+
+// noTerminalError traduce el mensaje al mostrarse, ya con el idioma del perfil
+type noTerminalError struct{}
+
+func (noTerminalError) Error() string {
+	return i18n.T("ui.no_terminal")
+}
+
+// SetupTerminal adapta la salida a donde se ejecuta orgmos. Sin terminal en
+// stdout, con TERM=dumb o con --plain se usa el modo plano: sin colores, sin
+// símbolos, sin animaciones y con preguntas línea por línea. NO_COLOR solo
+// quita los colores. Sin terminal en stdin no se muestran formularios y las
+// confirmaciones se rechazan, salvo que se acepten explícitamente con --yes.
+func SetupTerminal(plain bool, yes bool) {
+	interactive = term.IsTerminal(os.Stdin.Fd())
+	plainMode = plain || !term.IsTerminal(os.Stdout.Fd()) || os.Getenv("TERM") == "dumb"
+	assumeYes = yes
+
+	if plainMode || os.Getenv("NO_COLOR") != "" {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		// Sin colores no hace falta consultar el fondo de la terminal
+		lipgloss.SetHasDarkBackground(true)
+	}
+}
+
+// Plain indica si la salida está en modo plano
+func Plain() bool {
+	return plainMode
+}
+
+// Interactive indica si se le pueden hacer preguntas al usuario
+func Interactive() bool {
+	return interactive
+}
+
+// AssumeYes indica si las confirmaciones se dan por aceptadas sin preguntar
+func AssumeYes() bool {
+	return assumeYes
+}
+
+// RunSpinner ejecuta action mostrando un spinner con title. En modo plano
+// solo imprime el título, sin animación, y en modo JSON no muestra nada.
+func RunSpinner(title string, action func()) {
+	if JSONOutput() {
+		action()
+		return
+	}
+	if plainMode {
+		fmt.Println(title)
+		action()
+		return
+	}
+
+	spinner.New().
+		Title(title).
+		Action(action).
+		Run()
+}
+
+// ListItem formatea un elemento de una lista con viñeta
+func ListItem(text string) string {
+	if plainMode {
+		return Dim("  - " + text)
+	}
+	return Dim("  • " + text)
+}
+
+// NewTable crea una tabla con el borde redondeado de orgmos. En modo plano no
+// lleva bordes y las columnas se separan solo con espacios.
+func NewTable() *table.Table {
+	if plainMode {
+		return table.New().
+			Border(lipgloss.HiddenBorder()).
+			BorderTop(false).
+			BorderBottom(false).
+			BorderLeft(false).
+			BorderRight(false).
+			BorderHeader(false)
+	}
+
+	return table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(Gray))
+}
@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+
+	"orgmos/internal/i18n"
+)
+
+// ThemePywal usa los colores que pywal generó para el wallpaper actual
+const ThemePywal = "pywal"
+
+// Palette son los colores de la interfaz según su función
+type Palette struct {
+	Info      string // Mensajes informativos
+	Highlight string // Títulos y destacados
+	Success   string // Éxito
+	Warning   string // Advertencias
+	Error     string // Errores
+	Text      string // Texto normal
+	Muted     string // Texto secundario
+}
+
+// palettes son los temas integrados; "orgmos" son los colores de siempre
+var palettes = map[string]Palette{
+	"orgmos": {
+		Info: "#0066CC", Highlight: "#87CEEB", Success: "#00AA00", Warning: "#FFCC00",
+		Error: "#CC0000", Text: "#FFFFFF", Muted: "#808080",
+	},
+	"tokyonight": {
+		Info: "#7AA2F7", Highlight: "#7DCFFF", Success: "#9ECE6A", Warning: "#E0AF68",
+		Error: "#F7768E", Text: "#C0CAF5", Muted: "#565F89",
+	},
+	"catppuccin": {
+		Info: "#89B4FA", Highlight: "#89DCEB", Success: "#A6E3A1", Warning: "#F9E2AF",
+		Error: "#F38BA8", Text: "#CDD6F4", Muted: "#7F849C",
+	},
+	"high-contrast": {
+		Info: "#00D7FF", Highlight: "#FFFF00", Success: "#00FF00", Warning: "#FFD700",
+		Error: "#FF5F5F", Text: "#FFFFFF", Muted: "#D0D0D0",
+	},
+}
+
+// DefaultTheme es el tema que se usa si no se configura otro ni hay colores de pywal
+const DefaultTheme = "orgmos"
+
+// Themes retorna los nombres de los temas disponibles
+func Themes() []string {
+	names := make([]string, 0, len(palettes)+1)
+	for name := range palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append(names, ThemePywal)
+}
+
+// LoadTheme aplica el tema name con los colores de overrides encima (claves
+// info, highlight, success, warning, error, text y muted). Sin name se usan
+// los colores de pywal si existe walColors y si no DefaultTheme. Ante un
+// error se mantiene DefaultTheme.
+func LoadTheme(name string, overrides map[string]string, walColors string) error {
+	palette := palettes[DefaultTheme]
+
+	switch {
+	case name == "":
+		if wal, err := LoadPywalPalette(walColors); err == nil {
+			palette = wal
+		}
+	case name == ThemePywal:
+		wal, err := LoadPywalPalette(walColors)
+		if err != nil {
+			return err
+		}
+		palette = wal
+	default:
+		builtin, ok := palettes[strings.ToLower(name)]
+		if !ok {
+			return fmt.Errorf(i18n.T("ui.unknown_theme"), name, strings.Join(Themes(), ", "))
+		}
+		palette = builtin
+	}
+
+	for role, color := range overrides {
+		if err := palette.set(role, color); err != nil {
+			return err
+		}
+	}
+
+	SetPalette(palette)
+	return nil
+}
+
+// hexColorRe valida los colores de la configuración: #RGB o #RRGGBB
+var hexColorRe = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// set cambia el color de una función de la paleta
+func (p *Palette) set(role string, color string) error {
+	if !hexColorRe.MatchString(color) {
+		return fmt.Errorf(i18n.T("ui.invalid_color"), color, role)
+	}
+
+	switch strings.ToLower(role) {
+	case "info":
+		p.Info = color
+	case "highlight":
+		p.Highlight = color
+	case "success":
+		p.Success = color
+	case "warning":
+		p.Warning = color
+	case "error":
+		p.Error = color
+	case "text":
+		p.Text = color
+	case "muted":
+		p.Muted = color
+	default:
+		return fmt.Errorf(i18n.T("ui.unknown_color"), role)
+	}
+	return nil
+}
+
+// pywalColors es la parte de ~/.cache/wal/colors.json que se usa
+type pywalColors struct {
+	Special struct {
+		Foreground string `json:"foreground"`
+	} `json:"special"`
+	Colors map[string]string `json:"colors"`
+}
+
+// LoadPywalPalette arma una paleta con los colores de pywal: los 8 primeros
+// son los de la terminal (1 rojo, 2 verde, 3 amarillo, 4 azul, 6 cian) y
+// color8 es la variante gris de color0
+func LoadPywalPalette(path string) (Palette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Palette{}, err
+	}
+
+	var wal pywalColors
+	if err := json.Unmarshal(data, &wal); err != nil {
+		return Palette{}, fmt.Errorf(i18n.T("ui.pywal_invalid"), path, err)
+	}
+
+	palette := Palette{
+		Info:      wal.Colors["color4"],
+		Highlight: wal.Colors["color6"],
+		Success:   wal.Colors["color2"],
+		Warning:   wal.Colors["color3"],
+		Error:     wal.Colors["color1"],
+		Text:      wal.Special.Foreground,
+		Muted:     wal.Colors["color8"],
+	}
+	for _, color := range []string{palette.Info, palette.Highlight, palette.Success, palette.Warning, palette.Error, palette.Text, palette.Muted} {
+		if color == "" {
+			return Palette{}, fmt.Errorf(i18n.T("ui.pywal_incomplete"), path)
+		}
+	}
+	return palette, nil
+}
+
+// SetPalette cambia los colores y reconstruye los estilos de texto
+func SetPalette(p Palette) {
+	Blue = lipgloss.Color(p.Info)
+	SkyBlue = lipgloss.Color(p.Highlight)
+	Green = lipgloss.Color(p.Success)
+	Yellow = lipgloss.Color(p.Warning)
+	Red = lipgloss.Color(p.Error)
+	White = lipgloss.Color(p.Text)
+	Gray = lipgloss.Color(p.Muted)
+
+	TitleStyle = TitleStyle.Foreground(SkyBlue)
+	InfoStyle = InfoStyle.Foreground(Blue)
+	SuccessStyle = SuccessStyle.Foreground(Green)
+	WarningStyle = WarningStyle.Foreground(Yellow)
+	ErrorStyle = ErrorStyle.Foreground(Red)
+	HighlightStyle = HighlightStyle.Foreground(SkyBlue)
+	DimStyle = DimStyle.Foreground(Gray)
+}
+
+// formTheme arma el tema de huh con los colores actuales
+func formTheme() *huh.Theme {
+	t := huh.ThemeBase()
+
+	t.Focused.Base = t.Focused.Base.BorderForeground(Gray)
+	t.Focused.Title = t.Focused.Title.Foreground(SkyBlue).Bold(true)
+	t.Focused.NoteTitle = t.Focused.NoteTitle.Foreground(SkyBlue).Bold(true).MarginBottom(1)
+	t.Focused.Directory = t.Focused.Directory.Foreground(Blue)
+	t.Focused.Description = t.Focused.Description.Foreground(Gray)
+	t.Focused.ErrorIndicator = t.Focused.ErrorIndicator.Foreground(Red)
+	t.Focused.ErrorMessage = t.Focused.ErrorMessage.Foreground(Red)
+	t.Focused.SelectSelector = t.Focused.SelectSelector.Foreground(Blue)
+	t.Focused.NextIndicator = t.Focused.NextIndicator.Foreground(Blue)
+	t.Focused.PrevIndicator = t.Focused.PrevIndicator.Foreground(Blue)
+	t.Focused.Option = t.Focused.Option.Foreground(White)
+	t.Focused.MultiSelectSelector = t.Focused.MultiSelectSelector.Foreground(Blue)
+	t.Focused.SelectedOption = t.Focused.SelectedOption.Foreground(Green)
+	t.Focused.SelectedPrefix = lipgloss.NewStyle().Foreground(Green).SetString("✓ ")
+	t.Focused.UnselectedPrefix = lipgloss.NewStyle().Foreground(Gray).SetString("• ")
+	t.Focused.UnselectedOption = t.Focused.UnselectedOption.Foreground(White)
+	t.Focused.FocusedButton = t.Focused.FocusedButton.Foreground(lipgloss.Color("#000000")).Background(Blue).Bold(true)
+	t.Focused.Next = t.Focused.FocusedButton
+	t.Focused.BlurredButton = t.Focused.BlurredButton.Foreground(White).Background(lipgloss.Color("#303030"))
+
+	t.Focused.TextInput.Cursor = t.Focused.TextInput.Cursor.Foreground(Green)
+	t.Focused.TextInput.Placeholder = t.Focused.TextInput.Placeholder.Foreground(Gray)
+	t.Focused.TextInput.Prompt = t.Focused.TextInput.Prompt.Foreground(Blue)
+
+	t.Blurred = t.Focused
+	t.Blurred.Base = t.Focused.Base.BorderStyle(lipgloss.HiddenBorder())
+	t.Blurred.NextIndicator = lipgloss.NewStyle()
+	t.Blurred.PrevIndicator = lipgloss.NewStyle()
+
+	return t
+}
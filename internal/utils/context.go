@@ -0,0 +1,259 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"orgmos/internal/i18n"
+)
+
+// Operaciones con tiempo límite propio (timeouts.<operación> en ~/.orgmos.yaml)
+const (
+	OpQuery    = "query"    // Consultas al gestor de paquetes
+	OpGit      = "git"      // git clone y git pull
+	OpDownload = "download" // curl, wget y scripts de instalación remotos
+	OpInstall  = "install"  // Instalaciones, desinstalaciones y compilaciones
+)
+
+// defaultTimeouts son los tiempos límite por defecto; 0 significa sin límite.
+// Las instalaciones no tienen límite porque pueden pedir contraseña o tardar mucho.
+var defaultTimeouts = map[string]time.Duration{
+	OpQuery:    2 * time.Minute,
+	OpGit:      5 * time.Minute,
+	OpDownload: 10 * time.Minute,
+	OpInstall:  0,
+}
+
+// killDelay es cuánto se espera a que un proceso termine tras la señal antes de matarlo
+const killDelay = 5 * time.Second
+
+var (
+	rootContext = context.Background()
+	timeouts    = make(map[string]time.Duration)
+
+	// cancelSignal es la señal que se reenvía a los procesos hijos al cancelar
+	cancelSignal   os.Signal = syscall.SIGTERM
+	cancelSignalMu sync.Mutex
+
+	cleanups    = make(map[int]func())
+	nextCleanup int
+	cleanupsMu  sync.Mutex
+
+	// Comandos en ejecución y canales a cerrar cuando no quede ninguno. Un
+	// sync.WaitGroup no sirve: se agregan comandos mientras la señal espera.
+	running     = make(map[int]struct{})
+	nextRunning int
+	idle        []chan struct{}
+	runningMu   sync.Mutex
+)
+
+// SetContext define el contexto raíz de orgmos (cancelado con Ctrl+C o SIGTERM)
+func SetContext(ctx context.Context) {
+	rootContext = ctx
+}
+
+// Context retorna el contexto raíz de orgmos
+func Context() context.Context {
+	return rootContext
+}
+
+// SetTimeout cambia el tiempo límite de una operación; 0 lo desactiva
+func SetTimeout(op string, timeout time.Duration) {
+	timeouts[op] = timeout
+}
+
+// Timeout retorna el tiempo límite de una operación
+func Timeout(op string) time.Duration {
+	if timeout, ok := timeouts[op]; ok {
+		return timeout
+	}
+	return defaultTimeouts[op]
+}
+
+// OperationContext deriva de ctx un contexto con el tiempo límite de la operación
+func OperationContext(ctx context.Context, op string) (context.Context, context.CancelFunc) {
+	if timeout := Timeout(op); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// operationFor deduce la operación de un comando: git y las descargas tienen
+// su propio tiempo límite, el resto usa el indicado
+func operationFor(name string, fallback string) string {
+	switch filepath.Base(name) {
+	case "git":
+		return OpGit
+	case "curl", "wget":
+		return OpDownload
+	}
+	return fallback
+}
+
+// OnCancel registra una limpieza (ej: borrar un directorio temporal) que se
+// ejecuta si orgmos se interrumpe. Retorna una función para quitarla.
+func OnCancel(cleanup func()) func() {
+	cleanupsMu.Lock()
+	defer cleanupsMu.Unlock()
+
+	id := nextCleanup
+	nextCleanup++
+	cleanups[id] = cleanup
+
+	return func() {
+		cleanupsMu.Lock()
+		delete(cleanups, id)
+		cleanupsMu.Unlock()
+	}
+}
+
+// runCleanups ejecuta las limpiezas registradas con OnCancel
+func runCleanups() {
+	cleanupsMu.Lock()
+	pending := cleanups
+	cleanups = make(map[int]func())
+	cleanupsMu.Unlock()
+
+	for _, cleanup := range pending {
+		cleanup()
+	}
+}
+
+// HandleSignals crea el contexto raíz y atiende SIGINT/SIGTERM: la primera señal
+// cancela el contexto (y con él los procesos hijos), espera a que terminen,
+// ejecuta las limpiezas y sale. Una segunda señal sale de inmediato.
+func HandleSignals(parent context.Context) context.Context {
+	ctx, cancel := context.WithCancel(parent)
+
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		sig := <-signals
+
+		cancelSignalMu.Lock()
+		cancelSignal = sig
+		cancelSignalMu.Unlock()
+		cancel()
+
+		go func() {
+			<-signals
+			os.Exit(exitCode(sig))
+		}()
+
+		// Esperar a que los procesos hijos terminen, con un límite
+		select {
+		case <-allFinished():
+		case <-time.After(killDelay * 2):
+		}
+
+		runCleanups()
+		os.Exit(exitCode(sig))
+	}()
+
+	SetContext(ctx)
+	return ctx
+}
+
+// exitCode retorna el código de salida convencional para una señal (128 + número)
+func exitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return 1
+}
+
+// forwardedSignal retorna la señal que se reenvía a los hijos al cancelar
+func forwardedSignal() os.Signal {
+	cancelSignalMu.Lock()
+	defer cancelSignalMu.Unlock()
+	return cancelSignal
+}
+
+// newCommand crea un comando ligado a ctx. Al cancelarse ctx (Ctrl+C, SIGTERM o
+// tiempo límite) la señal se reenvía al comando y, si no es interactivo, a todo su
+// grupo de procesos; si no termina en killDelay se mata.
+//
+// Los comandos interactivos comparten el grupo de procesos de orgmos para poder
+// leer de la terminal (sudo pide la contraseña en ella); Ctrl+C ya les llega
+// directamente desde la terminal.
+func newCommand(ctx context.Context, interactive bool, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.WaitDelay = killDelay
+
+	if interactive {
+		cmd.Cancel = func() error {
+			return cmd.Process.Signal(forwardedSignal())
+		}
+		return cmd
+	}
+
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		sig, ok := forwardedSignal().(syscall.Signal)
+		if !ok {
+			sig = syscall.SIGTERM
+		}
+		// El pid negativo envía la señal a todo el grupo de procesos
+		return syscall.Kill(-cmd.Process.Pid, sig)
+	}
+	return cmd
+}
+
+// track registra un comando en ejecución para que la salida por señal espere a
+// que termine. Se usa como: defer track()()
+func track() func() {
+	runningMu.Lock()
+	id := nextRunning
+	nextRunning++
+	running[id] = struct{}{}
+	runningMu.Unlock()
+
+	return func() {
+		runningMu.Lock()
+		defer runningMu.Unlock()
+
+		delete(running, id)
+		if len(running) == 0 {
+			for _, ch := range idle {
+				close(ch)
+			}
+			idle = nil
+		}
+	}
+}
+
+// allFinished retorna un canal que se cierra cuando no quedan comandos en ejecución
+func allFinished() <-chan struct{} {
+	runningMu.Lock()
+	defer runningMu.Unlock()
+
+	ch := make(chan struct{})
+	if len(running) == 0 {
+		close(ch)
+		return ch
+	}
+	idle = append(idle, ch)
+	return ch
+}
+
+// commandError explica el error de un comando cancelado o que superó su tiempo límite
+func commandError(ctx context.Context, name string, op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return fmt.Errorf(i18n.T("utils.timed_out"), name, Timeout(op), err)
+	case context.Canceled:
+		return fmt.Errorf(i18n.T("utils.cancelled"), name, err)
+	}
+	return err
+}
@@ -2,12 +2,17 @@ package utils
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
+	"syscall"
 
+	"orgmos/internal/i18n"
 	"orgmos/internal/ui"
 )
 
@@ -16,56 +21,251 @@ func IsRoot() bool {
 	return os.Geteuid() == 0
 }
 
-// RunCommandWithSudo ejecuta un comando con sudo si no es root
+// RunCommandWithSudo ejecuta un comando con sudo (o doas/run0) si no es root
 func RunCommandWithSudo(name string, args ...string) error {
 	if IsRoot() {
 		// Si es root, ejecutar directamente
 		return RunCommand(name, args...)
 	}
-	// Si no es root, usar sudo
-	fullArgs := append([]string{name}, args...)
-	return RunCommand("sudo", fullArgs...)
+	// Si no es root, usar la herramienta de elevación
+	tool, fullArgs := privilegedArgs(name, args...)
+	return RunCommand(tool, fullArgs...)
 }
 
 // RunCommand ejecuta un comando y muestra la salida
 func RunCommand(name string, args ...string) error {
-	cmd := exec.Command(name, args...)
+	return RunCommandContext(Context(), name, args...)
+}
+
+// RunCommandContext ejecuta un comando ligado a ctx y muestra la salida.
+// Se cancela con ctx o al superar el tiempo límite de su operación.
+func RunCommandContext(ctx context.Context, name string, args ...string) error {
+	return runInteractive(ctx, newCommand, name, args...)
+}
+
+// RunCommandTerminal es como RunCommand pero le pasa la terminal tal cual, sin
+// copiar la salida al log: el comando puede mostrar formularios y animaciones,
+// ej: otro proceso de orgmos, que guarda su propio log
+func RunCommandTerminal(name string, args ...string) error {
+	ctx, cancel := OperationContext(Context(), OpInstall)
+	defer cancel()
+	defer track()()
+
+	log := newTranscript(name, args)
+	cmd := newCommand(ctx, true, name, args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
 
 	err := cmd.Run()
-	if err != nil {
-		return err
+	log.finish(err)
+	return commandError(ctx, name, OpInstall, err)
+}
+
+// runInteractive ejecuta el comando creado por build conectado a la terminal
+func runInteractive(ctx context.Context, build func(context.Context, bool, string, ...string) *exec.Cmd, name string, args ...string) error {
+	op := operationFor(name, OpInstall)
+	ctx, cancel := OperationContext(ctx, op)
+	defer cancel()
+	defer track()()
+
+	log := newTranscript(name, args)
+	cmd := build(ctx, true, name, args...)
+	cmd.Stdout = io.MultiWriter(os.Stdout, &log.stdout)
+	cmd.Stderr = io.MultiWriter(os.Stderr, &log.stderr)
+	cmd.Stdin = os.Stdin
+
+	err := cmd.Run()
+	log.finish(err)
+	return commandError(ctx, name, op, err)
+}
+
+// captureLimit es la cantidad máxima de salida que se conserva al capturar un comando
+const captureLimit = 64 * 1024
+
+// tailBuffer guarda solo los últimos limit bytes escritos (0 = sin límite).
+// Es seguro escribir desde stdout y stderr a la vez.
+type tailBuffer struct {
+	mu    sync.Mutex
+	data  []byte
+	limit int
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.data = append(b.data, p...)
+	if b.limit > 0 && len(b.data) > b.limit {
+		b.data = b.data[len(b.data)-b.limit:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.data)
+}
+
+// RunCommandCapture ejecuta un comando mostrando la salida y además la retorna
+// (solo el final si es muy larga) para poder analizar los errores
+func RunCommandCapture(name string, args ...string) (string, error) {
+	return RunCommandCaptureContext(Context(), name, args...)
+}
+
+// RunCommandCaptureContext es como RunCommandCapture pero ligado a ctx
+func RunCommandCaptureContext(ctx context.Context, name string, args ...string) (string, error) {
+	return runCapture(ctx, newCommand, nil, name, args...)
+}
+
+// RunCommandOutput es como RunCommandCapture pero escribe la salida en out en
+// lugar de la terminal, ej: para una vista de progreso. El comando no recibe
+// entrada. Con out nil equivale a RunCommandCapture.
+func RunCommandOutput(out io.Writer, name string, args ...string) (string, error) {
+	return runCapture(Context(), newCommand, out, name, args...)
+}
+
+// runCapture ejecuta el comando creado por build mostrando y capturando su
+// salida. Con out nil el comando usa la terminal; si no, escribe en out y no
+// recibe entrada.
+func runCapture(ctx context.Context, build func(context.Context, bool, string, ...string) *exec.Cmd, out io.Writer, name string, args ...string) (string, error) {
+	op := operationFor(name, OpInstall)
+	ctx, cancel := OperationContext(ctx, op)
+	defer cancel()
+	defer track()()
+
+	captured := tailBuffer{limit: captureLimit}
+	log := newTranscript(name, args)
+	cmd := build(ctx, true, name, args...)
+	if out == nil {
+		cmd.Stdout = io.MultiWriter(os.Stdout, &captured, &log.stdout)
+		cmd.Stderr = io.MultiWriter(os.Stderr, &captured, &log.stderr)
+		cmd.Stdin = os.Stdin
+	} else {
+		cmd.Stdout = io.MultiWriter(out, &captured, &log.stdout)
+		cmd.Stderr = io.MultiWriter(out, &captured, &log.stderr)
+	}
+
+	err := cmd.Run()
+	log.finish(err)
+	return captured.String(), commandError(ctx, name, op, err)
+}
+
+// RunCommandWithSudoCapture es como RunCommandCapture pero usa sudo (o doas/run0) si no es root
+func RunCommandWithSudoCapture(name string, args ...string) (string, error) {
+	if IsRoot() {
+		return RunCommandCapture(name, args...)
 	}
+	tool, fullArgs := privilegedArgs(name, args...)
+	return RunCommandCapture(tool, fullArgs...)
+}
 
-	return nil
+// RunCommandWithSudoOutput es como RunCommandOutput pero usa sudo (o doas/run0) si no es root
+func RunCommandWithSudoOutput(out io.Writer, name string, args ...string) (string, error) {
+	if IsRoot() {
+		return RunCommandOutput(out, name, args...)
+	}
+	tool, fullArgs := privilegedArgs(name, args...)
+	return RunCommandOutput(out, tool, fullArgs...)
 }
 
 // RunCommandSilent ejecuta un comando sin mostrar salida
 func RunCommandSilent(name string, args ...string) (string, error) {
-	cmd := exec.Command(name, args...)
-	output, err := cmd.CombinedOutput()
+	return RunCommandSilentContext(Context(), name, args...)
+}
+
+// RunCommandSilentContext es como RunCommandSilent pero ligado a ctx. El comando
+// corre en su propio grupo de procesos, que recibe la señal completa al cancelar.
+func RunCommandSilentContext(ctx context.Context, name string, args ...string) (string, error) {
+	return runSilent(ctx, newCommand, name, args...)
+}
+
+// RunCommandSilentEnv es como RunCommandSilent pero agrega variables de entorno
+// al comando, ej: LC_ALL para leer campos que el comando traduce según el idioma
+func RunCommandSilentEnv(env []string, name string, args ...string) (string, error) {
+	build := func(ctx context.Context, interactive bool, name string, args ...string) *exec.Cmd {
+		cmd := newCommand(ctx, interactive, name, args...)
+		cmd.Env = append(os.Environ(), env...)
+		return cmd
+	}
+	return runSilent(Context(), build, name, args...)
+}
+
+// runSilent ejecuta el comando creado por build sin mostrar su salida
+func runSilent(ctx context.Context, build func(context.Context, bool, string, ...string) *exec.Cmd, name string, args ...string) (string, error) {
+	op := operationFor(name, OpQuery)
+	ctx, cancel := OperationContext(ctx, op)
+	defer cancel()
+	defer track()()
+
+	var combined tailBuffer
+	log := newTranscript(name, args)
+	cmd := build(ctx, false, name, args...)
+	cmd.Stdout = io.MultiWriter(&combined, &log.stdout)
+	cmd.Stderr = io.MultiWriter(&combined, &log.stderr)
+
+	err := cmd.Run()
+	log.finish(err)
+	output := combined.String()
+	if ctx.Err() != nil && cmd.Process != nil {
+		// Matar lo que quede del grupo, como procesos en segundo plano que ignoran SIGINT
+		syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
 	if err != nil {
-		return string(output), err
+		return output, commandError(ctx, name, op, err)
 	}
 
-	return strings.TrimSpace(string(output)), nil
+	return strings.TrimSpace(output), nil
+}
+
+// RunRemoteScript equivale a 'curl -fsSL url | sh', pero descarga el script
+// completo antes de ejecutarlo: una descarga cortada o cancelada no deja un script
+// a medio ejecutar. La descarga tiene el tiempo límite de OpDownload y ambos
+// pasos se cancelan con el contexto raíz.
+func RunRemoteScript(url string) error {
+	script, err := RunCommandSilent("curl", "-fsSL", url)
+	if err != nil {
+		return fmt.Errorf(i18n.T("utils.download_failed"), url, err)
+	}
+
+	ctx, cancel := OperationContext(Context(), OpInstall)
+	defer cancel()
+	defer track()()
+
+	log := newTranscript("sh", []string{url})
+	cmd := newCommand(ctx, true, "sh")
+	cmd.Stdin = strings.NewReader(script + "\n")
+	cmd.Stdout = io.MultiWriter(os.Stdout, &log.stdout)
+	cmd.Stderr = io.MultiWriter(os.Stderr, &log.stderr)
+
+	err = cmd.Run()
+	log.finish(err)
+	return commandError(ctx, "sh", OpInstall, err)
 }
 
 // RunCommandWithConfirm ejecuta un comando después de confirmación
 func RunCommandWithConfirm(message string, name string, args ...string) error {
 	fmt.Println(ui.Info(message))
-	fmt.Print(ui.Highlight("¿Continuar? [Y/n]: "))
 
-	reader := bufio.NewReader(os.Stdin)
-	response, _ := reader.ReadString('\n')
-	response = strings.TrimSpace(strings.ToLower(response))
-
-	if response != "" && response != "y" && response != "yes" && response != "s" && response != "si" {
-		fmt.Println(ui.Warning("Operación cancelada"))
+	// Con --yes se continúa sin preguntar; sin terminal no hay a quién preguntar
+	if !ui.AssumeYes() && !ui.Interactive() {
+		fmt.Println(ui.Warning(i18n.T("ui.needs_terminal")))
+		fmt.Println(ui.Warning(i18n.T("common.operation_cancelled")))
 		return nil
 	}
+	if !ui.AssumeYes() {
+		fmt.Print(ui.Highlight(i18n.T("utils.continue_prompt")))
+
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+
+		if response != "" && response != "y" && response != "yes" && response != "s" && response != "si" {
+			fmt.Println(ui.Warning(i18n.T("common.operation_cancelled")))
+			return nil
+		}
+	}
 
 	return RunCommand(name, args...)
 }
@@ -83,7 +283,7 @@ func CheckDependency(cmd string) bool {
 
 // NotifyMissingDependency notifica que falta una dependencia usando swaync o notify-send
 func NotifyMissingDependency(cmd string) {
-	msg := fmt.Sprintf("Dependencia faltante: %s. Instálala con tu gestor de paquetes.", cmd)
+	msg := i18n.T("utils.missing_dependency", cmd)
 
 	// Intentar con swaync-client primero
 	if CommandExists("swaync-client") {
@@ -137,6 +337,6 @@ func GetRepoDir() string {
 	}
 
 	// Fallback a ubicación conocida
-	homeDir, _ := os.UserHomeDir()
+	homeDir, _ := GetHomeDir()
 	return filepath.Join(homeDir, "Myconfig")
 }
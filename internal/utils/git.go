@@ -1,13 +1,13 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
-	"github.com/charmbracelet/huh/spinner"
-
+	"orgmos/internal/i18n"
 	"orgmos/internal/ui"
 )
 
@@ -21,7 +21,7 @@ func UpdateRepo() error {
 		return nil
 	}
 
-	fmt.Println(ui.Info("Actualizando repositorio..."))
+	fmt.Println(ui.Info(i18n.T("git.updating_repo")))
 
 	// Cambiar al directorio del repo
 	oldDir, _ := os.Getwd()
@@ -31,7 +31,7 @@ func UpdateRepo() error {
 	defer os.Chdir(oldDir)
 
 	// Git pull
-	output, err := RunCommandSilent("git", "pull", "--rebase")
+	output, err := RunUserCommandSilent("git", "pull", "--rebase")
 	if err != nil {
 		// Analizar el tipo de error
 		outputLower := strings.ToLower(output)
@@ -43,14 +43,14 @@ func UpdateRepo() error {
 			strings.Contains(outputLower, "network is unreachable") ||
 			strings.Contains(outputLower, "no route to host") ||
 			strings.Contains(outputLower, "name or service not known") {
-			fmt.Println(ui.Warning("No se pudo actualizar: sin conexión a internet"))
+			fmt.Println(ui.Warning(i18n.T("git.update_offline")))
 			return nil
 		}
 
 		// Detectar si ya está actualizado (a veces git devuelve error pero está actualizado)
 		if strings.Contains(outputLower, "already up to date") ||
 			strings.Contains(outputLower, "ya está actualizado") {
-			fmt.Println(ui.Dim("Repositorio ya actualizado"))
+			fmt.Println(ui.Dim(i18n.T("git.repo_up_to_date")))
 			return nil
 		}
 
@@ -61,13 +61,13 @@ func UpdateRepo() error {
 			strings.Contains(outputLower, "conflict") ||
 			strings.Contains(outputLower, "unmerged paths") ||
 			strings.Contains(outputLower, "needs merge") {
-			fmt.Println(ui.Warning("No se pudo actualizar: hay cambios locales que necesitan ser actualizados primero"))
-			fmt.Println(ui.Dim("Ejecuta 'git status' para ver los cambios y resuélvelos manualmente"))
+			fmt.Println(ui.Warning(i18n.T("git.update_local_changes")))
+			fmt.Println(ui.Dim(i18n.T("git.local_changes_hint")))
 			return nil
 		}
 
 		// Error genérico
-		fmt.Println(ui.Warning("No se pudo actualizar el repositorio"))
+		fmt.Println(ui.Warning(i18n.T("git.update_failed")))
 		return nil // No es error fatal
 	}
 
@@ -76,9 +76,9 @@ func UpdateRepo() error {
 	if strings.Contains(outputLower, "already up to date") ||
 		strings.Contains(outputLower, "ya está actualizado") ||
 		output == "" {
-		fmt.Println(ui.Dim("Repositorio ya actualizado"))
+		fmt.Println(ui.Dim(i18n.T("git.repo_up_to_date")))
 	} else {
-		fmt.Println(ui.Success("Repositorio actualizado"))
+		fmt.Println(ui.Success(i18n.T("git.repo_updated")))
 	}
 
 	return nil
@@ -86,13 +86,13 @@ func UpdateRepo() error {
 
 // CreateDesktopFile crea el archivo .desktop para orgmos
 func CreateDesktopFile() error {
-	homeDir, err := os.UserHomeDir()
+	homeDir, err := GetHomeDir()
 	if err != nil {
 		return err
 	}
 
 	applicationsDir := filepath.Join(homeDir, ".local", "share", "applications")
-	if err := os.MkdirAll(applicationsDir, 0755); err != nil {
+	if err := MkdirAllForUser(applicationsDir, 0755); err != nil {
 		return err
 	}
 
@@ -108,20 +108,29 @@ Keywords=config;setup;system;
 `
 
 	desktopPath := filepath.Join(applicationsDir, "orgmos.desktop")
-	if err := os.WriteFile(desktopPath, []byte(desktopContent), 0755); err != nil {
+	if err := WriteFileForUser(desktopPath, []byte(desktopContent), 0755); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// GetOrgmosConfigDir obtiene el directorio de configuración de orgmos (~/.config/orgmos)
+func GetOrgmosConfigDir() string {
+	homeDir, err := GetHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".config", "orgmos")
+}
+
 // GetConfigRepoDir obtiene el directorio del repositorio para archivos de config
 func GetConfigRepoDir() string {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
+	configDir := GetOrgmosConfigDir()
+	if configDir == "" {
 		return ""
 	}
-	return filepath.Join(homeDir, ".config", "orgmos", "repo")
+	return filepath.Join(configDir, "repo")
 }
 
 // DownloadConfigFiles descarga/actualiza el repositorio en ~/.config/orgmos/repo/
@@ -129,45 +138,45 @@ func GetConfigRepoDir() string {
 func DownloadConfigFiles() error {
 	configRepoDir := GetConfigRepoDir()
 	if configRepoDir == "" {
-		return fmt.Errorf("no se pudo obtener directorio de configuración")
+		return errors.New(i18n.T("git.config_dir_failed"))
 	}
 
 	repoURL := "https://github.com/osmargm1202/Myconfig.git"
 
 	// Si el directorio no existe, clonar el repositorio
 	if _, err := os.Stat(configRepoDir); os.IsNotExist(err) {
-		fmt.Println(ui.Info("Clonando repositorio para archivos de configuración..."))
+		fmt.Println(ui.Info(i18n.T("git.cloning_config_repo")))
 
 		// Crear directorio padre
-		if err := os.MkdirAll(filepath.Dir(configRepoDir), 0755); err != nil {
-			return fmt.Errorf("error creando directorio: %w", err)
+		if err := MkdirAllForUser(filepath.Dir(configRepoDir), 0755); err != nil {
+			return fmt.Errorf(i18n.T("git.mkdir_failed"), err)
 		}
 
 		// Clonar repositorio
-		output, err := RunCommandSilent("git", "clone", repoURL, configRepoDir)
+		output, err := RunUserCommandSilent("git", "clone", repoURL, configRepoDir)
 		if err != nil {
-			return fmt.Errorf("error clonando repositorio: %s - %w", output, err)
+			return fmt.Errorf(i18n.T("git.clone_failed"), output, err)
 		}
 
-		fmt.Println(ui.Success("Repositorio clonado para archivos de configuración"))
+		fmt.Println(ui.Success(i18n.T("git.config_repo_cloned")))
 		return nil
 	}
 
 	// Si existe, actualizar
-	fmt.Println(ui.Info("Actualizando repositorio de configuración..."))
+	fmt.Println(ui.Info(i18n.T("git.updating_config_repo")))
 
 	// Cambiar al directorio del repo
 	oldDir, _ := os.Getwd()
 	if err := os.Chdir(configRepoDir); err != nil {
-		return fmt.Errorf("error cambiando a directorio del repo: %w", err)
+		return fmt.Errorf(i18n.T("git.chdir_failed"), err)
 	}
 	defer os.Chdir(oldDir)
 
 	// Git pull
-	output, err := RunCommandSilent("git", "pull", "--rebase")
+	output, err := RunUserCommandSilent("git", "pull", "--rebase")
 	if err != nil {
 		// No es error fatal, continuar con lo que hay
-		fmt.Println(ui.Warning("No se pudo actualizar el repositorio de configuración"))
+		fmt.Println(ui.Warning(i18n.T("git.config_update_failed")))
 		return nil
 	}
 
@@ -176,9 +185,9 @@ func DownloadConfigFiles() error {
 	if strings.Contains(outputLower, "already up to date") ||
 		strings.Contains(outputLower, "ya está actualizado") ||
 		output == "" {
-		fmt.Println(ui.Dim("Repositorio de configuración ya actualizado"))
+		fmt.Println(ui.Dim(i18n.T("git.config_repo_up_to_date")))
 	} else {
-		fmt.Println(ui.Success("Repositorio de configuración actualizado"))
+		fmt.Println(ui.Success(i18n.T("git.config_repo_updated")))
 	}
 
 	return nil
@@ -186,62 +195,75 @@ func DownloadConfigFiles() error {
 
 // GetDotfilesDir obtiene el directorio del repositorio dotfiles
 func GetDotfilesDir() string {
-	homeDir, err := os.UserHomeDir()
+	homeDir, err := GetHomeDir()
 	if err != nil {
 		return ""
 	}
 	return filepath.Join(homeDir, "Downloads", "dotfiles")
 }
 
+// GetDotfilesRevision obtiene el commit actual del repositorio dotfiles y su fecha
+func GetDotfilesRevision() (revision string, date string, err error) {
+	dotfilesDir := GetDotfilesDir()
+
+	output, err := RunUserCommandSilent("git", "-C", dotfilesDir, "log", "-1", "--format=%h|%cs")
+	if err != nil {
+		return "", "", fmt.Errorf(i18n.T("git.revision_failed"), err)
+	}
+
+	revision, date, _ = strings.Cut(strings.TrimSpace(output), "|")
+	return revision, date, nil
+}
+
 // CloneOrUpdateDotfiles clona o actualiza el repositorio dotfiles en ~/Downloads/dotfiles
 func CloneOrUpdateDotfiles() error {
 	dotfilesDir := GetDotfilesDir()
 	if dotfilesDir == "" {
-		return fmt.Errorf("no se pudo obtener directorio de dotfiles")
+		return errors.New(i18n.T("git.dotfiles_dir_failed"))
 	}
 
 	repoURL := "https://github.com/osmargm1202/dotfiles.git"
 
 	// Si el directorio no existe, clonar el repositorio
 	if _, err := os.Stat(dotfilesDir); os.IsNotExist(err) {
-		fmt.Println(ui.Info("Clonando repositorio dotfiles..."))
+		fmt.Println(ui.Info(i18n.T("git.cloning_dotfiles")))
 
 		// Crear directorio padre
-		if err := os.MkdirAll(filepath.Dir(dotfilesDir), 0755); err != nil {
-			return fmt.Errorf("error creando directorio: %w", err)
+		if err := MkdirAllForUser(filepath.Dir(dotfilesDir), 0755); err != nil {
+			return fmt.Errorf(i18n.T("git.mkdir_failed"), err)
 		}
 
 		// Clonar repositorio
-		output, err := RunCommandSilent("git", "clone", repoURL, dotfilesDir)
+		output, err := RunUserCommandSilent("git", "clone", repoURL, dotfilesDir)
 		if err != nil {
-			fmt.Println(ui.Warning(fmt.Sprintf("No se pudo clonar el repositorio dotfiles: %s", output)))
-			return fmt.Errorf("error clonando repositorio: %s - %w", output, err)
+			fmt.Println(ui.Warning(i18n.T("git.dotfiles_clone_failed", output)))
+			return fmt.Errorf(i18n.T("git.clone_failed"), output, err)
 		}
 
-		fmt.Println(ui.Success("Repositorio dotfiles clonado"))
+		fmt.Println(ui.Success(i18n.T("git.dotfiles_cloned")))
 		return nil
 	}
 
 	// Si existe, actualizar
-	fmt.Println(ui.Info("Actualizando repositorio dotfiles..."))
+	fmt.Println(ui.Info(i18n.T("git.updating_dotfiles")))
 
 	// Cambiar al directorio del repo
 	oldDir, _ := os.Getwd()
 	if err := os.Chdir(dotfilesDir); err != nil {
-		fmt.Println(ui.Warning(fmt.Sprintf("No se pudo cambiar al directorio dotfiles: %v", err)))
-		return fmt.Errorf("error cambiando a directorio del repo: %w", err)
+		fmt.Println(ui.Warning(i18n.T("git.dotfiles_chdir_failed", err)))
+		return fmt.Errorf(i18n.T("git.chdir_failed"), err)
 	}
 	defer os.Chdir(oldDir)
 
 	// Verificar si es un repositorio git válido
 	gitDir := filepath.Join(dotfilesDir, ".git")
 	if _, err := os.Stat(gitDir); os.IsNotExist(err) {
-		fmt.Println(ui.Warning("Directorio dotfiles existe pero no es un repositorio Git válido"))
-		return fmt.Errorf("directorio no es un repositorio git válido")
+		fmt.Println(ui.Warning(i18n.T("git.dotfiles_not_git")))
+		return errors.New(i18n.T("git.not_git_repo"))
 	}
 
 	// Git pull
-	output, err := RunCommandSilent("git", "pull", "--rebase")
+	output, err := RunUserCommandSilent("git", "pull", "--rebase")
 	if err != nil {
 		// Analizar el tipo de error
 		outputLower := strings.ToLower(output)
@@ -253,14 +275,14 @@ func CloneOrUpdateDotfiles() error {
 			strings.Contains(outputLower, "network is unreachable") ||
 			strings.Contains(outputLower, "no route to host") ||
 			strings.Contains(outputLower, "name or service not known") {
-			fmt.Println(ui.Warning("No se pudo actualizar dotfiles: sin conexión a internet"))
-			return fmt.Errorf("sin conexión a internet")
+			fmt.Println(ui.Warning(i18n.T("git.dotfiles_offline")))
+			return errors.New(i18n.T("git.offline"))
 		}
 
 		// Detectar si ya está actualizado
 		if strings.Contains(outputLower, "already up to date") ||
 			strings.Contains(outputLower, "ya está actualizado") {
-			fmt.Println(ui.Dim("Repositorio dotfiles ya actualizado"))
+			fmt.Println(ui.Dim(i18n.T("git.dotfiles_up_to_date")))
 			return nil
 		}
 
@@ -271,13 +293,13 @@ func CloneOrUpdateDotfiles() error {
 			strings.Contains(outputLower, "conflict") ||
 			strings.Contains(outputLower, "unmerged paths") ||
 			strings.Contains(outputLower, "needs merge") {
-			fmt.Println(ui.Warning("No se pudo actualizar dotfiles: hay cambios locales"))
-			return fmt.Errorf("cambios locales detectados")
+			fmt.Println(ui.Warning(i18n.T("git.dotfiles_local_changes")))
+			return errors.New(i18n.T("git.local_changes"))
 		}
 
 		// Error genérico
-		fmt.Println(ui.Warning(fmt.Sprintf("No se pudo actualizar el repositorio dotfiles: %s", output)))
-		return fmt.Errorf("error actualizando repositorio: %s", output)
+		fmt.Println(ui.Warning(i18n.T("git.dotfiles_update_failed", output)))
+		return errors.New(i18n.T("git.update_error", output))
 	}
 
 	// Verificar si ya estaba actualizado
@@ -285,9 +307,9 @@ func CloneOrUpdateDotfiles() error {
 	if strings.Contains(outputLower, "already up to date") ||
 		strings.Contains(outputLower, "ya está actualizado") ||
 		output == "" {
-		fmt.Println(ui.Dim("Repositorio dotfiles ya actualizado"))
+		fmt.Println(ui.Dim(i18n.T("git.dotfiles_up_to_date")))
 	} else {
-		fmt.Println(ui.Success("Repositorio dotfiles actualizado"))
+		fmt.Println(ui.Success(i18n.T("git.dotfiles_updated")))
 	}
 
 	return nil
@@ -297,12 +319,9 @@ func CloneOrUpdateDotfiles() error {
 func CloneOrUpdateDotfilesWithSpinner() error {
 	var cloneErr error
 
-	spinner.New().
-		Title("Clonando/actualizando repositorio dotfiles...").
-		Action(func() {
-			cloneErr = CloneOrUpdateDotfiles()
-		}).
-		Run()
+	ui.RunSpinner(i18n.T("git.syncing_dotfiles"), func() {
+		cloneErr = CloneOrUpdateDotfiles()
+	})
 
 	return cloneErr
 }
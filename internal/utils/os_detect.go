@@ -13,19 +13,19 @@ const (
 	DistroArch    DistroType = "arch"
 	DistroDebian  DistroType = "debian"
 	DistroUbuntu  DistroType = "ubuntu"
+	DistroFedora  DistroType = "fedora"
 	DistroUnknown DistroType = "unknown"
 )
 
-// DetectOS detecta el sistema operativo leyendo /etc/os-release
-func DetectOS() DistroType {
+// ReadOSRelease lee ID e ID_LIKE de /etc/os-release, normalizados a minúsculas
+func ReadOSRelease() (id string, idLike string) {
 	file, err := os.Open("/etc/os-release")
 	if err != nil {
-		return DistroUnknown
+		return "", ""
 	}
 	defer file.Close()
 
 	scanner := bufio.NewScanner(file)
-	var id, idLike string
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -38,9 +38,15 @@ func DetectOS() DistroType {
 		}
 	}
 
-	// Normalizar a minúsculas
-	id = strings.ToLower(id)
-	idLike = strings.ToLower(idLike)
+	return strings.ToLower(id), strings.ToLower(idLike)
+}
+
+// DetectOS detecta el sistema operativo leyendo /etc/os-release
+func DetectOS() DistroType {
+	id, idLike := ReadOSRelease()
+	if id == "" && idLike == "" {
+		return DistroUnknown
+	}
 
 	// Detectar distribución
 	switch id {
@@ -50,6 +56,8 @@ func DetectOS() DistroType {
 		return DistroUbuntu
 	case "debian", "raspbian", "kali", "parrot":
 		return DistroDebian
+	case "fedora", "nobara", "ultramarine":
+		return DistroFedora
 	}
 
 	// Verificar ID_LIKE si no se detectó directamente
@@ -62,6 +70,9 @@ func DetectOS() DistroType {
 	if strings.Contains(idLike, "debian") {
 		return DistroDebian
 	}
+	if strings.Contains(idLike, "fedora") || strings.Contains(idLike, "rhel") {
+		return DistroFedora
+	}
 
 	return DistroUnknown
 }
@@ -86,6 +97,11 @@ func IsUbuntu() bool {
 	return DetectOS() == DistroUbuntu
 }
 
+// IsFedora verifica si el sistema es Fedora o derivado
+func IsFedora() bool {
+	return DetectOS() == DistroFedora
+}
+
 // IsAptBased verifica si el sistema usa apt (Debian o Ubuntu)
 func IsAptBased() bool {
 	distro := DetectOS()
@@ -101,6 +117,8 @@ func GetDistroName() string {
 		return "Debian"
 	case DistroUbuntu:
 		return "Ubuntu"
+	case DistroFedora:
+		return "Fedora"
 	default:
 		return "Unknown"
 	}
@@ -113,6 +131,8 @@ func GetPackageManager() string {
 		return "pacman"
 	case DistroDebian, DistroUbuntu:
 		return "apt"
+	case DistroFedora:
+		return "dnf"
 	default:
 		return "unknown"
 	}
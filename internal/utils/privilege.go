@@ -0,0 +1,195 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"orgmos/internal/i18n"
+)
+
+// privilegeTools son las herramientas de elevación soportadas, en orden de preferencia
+var privilegeTools = []string{"sudo", "doas", "run0"}
+
+// keepaliveInterval es cada cuánto se renueva el ticket de sudo durante una corrida.
+// El timeout por defecto de sudo es de 5 minutos.
+const keepaliveInterval = time.Minute
+
+var (
+	configuredPrivilegeTool string
+	privilegeOnce           sync.Once
+	privilegeTool           string
+	keepaliveOnce           sync.Once
+)
+
+// SetPrivilegeTool fija la herramienta de elevación ("sudo", "doas" o "run0")
+// elegida en la configuración. Vacío para detectarla automáticamente.
+func SetPrivilegeTool(tool string) {
+	configuredPrivilegeTool = tool
+}
+
+// PrivilegeTool retorna la herramienta de elevación a usar: la configurada si
+// está instalada, o la primera disponible entre sudo, doas y run0
+func PrivilegeTool() string {
+	privilegeOnce.Do(func() {
+		if configuredPrivilegeTool != "" && CommandExists(configuredPrivilegeTool) {
+			privilegeTool = configuredPrivilegeTool
+			return
+		}
+		for _, tool := range privilegeTools {
+			if CommandExists(tool) {
+				privilegeTool = tool
+				return
+			}
+		}
+	})
+	return privilegeTool
+}
+
+// hasTTY indica si la entrada estándar es una terminal
+func hasTTY() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// askpassHelpers son rutas habituales de programas gráficos para pedir la contraseña de sudo
+var askpassHelpers = []string{
+	"/usr/lib/ssh/ssh-askpass",
+	"/usr/bin/ksshaskpass",
+	"/usr/bin/lxqt-openssh-askpass",
+	"/usr/lib/openssh/gnome-ssh-askpass",
+	"/usr/libexec/openssh/gnome-ssh-askpass",
+	"/usr/libexec/openssh/ssh-askpass",
+}
+
+// FindAskpass retorna el programa para SUDO_ASKPASS: el de la variable de entorno
+// o el primero instalado de los habituales
+func FindAskpass() string {
+	if askpass := os.Getenv("SUDO_ASKPASS"); askpass != "" {
+		return askpass
+	}
+	for _, helper := range askpassHelpers {
+		if _, err := os.Stat(helper); err == nil {
+			return helper
+		}
+	}
+	return ""
+}
+
+// DesktopLaunchEnv es la variable que pone el .desktop de 'orgmos install'. La
+// terminal que abre el lanzador queda ocupada por el menú, así que la contraseña
+// se pide con el programa gráfico de SUDO_ASKPASS.
+const DesktopLaunchEnv = "ORGMOS_DESKTOP"
+
+// useAskpass indica si sudo debe pedir la contraseña con SUDO_ASKPASS: al abrir
+// orgmos desde el .desktop o cuando no hay terminal donde preguntar
+func useAskpass() bool {
+	return os.Getenv("SUDO_ASKPASS") != "" && (os.Getenv(DesktopLaunchEnv) != "" || !hasTTY())
+}
+
+// privilegedArgs arma el comando con la herramienta de elevación delante
+func privilegedArgs(name string, args ...string) (string, []string) {
+	tool := PrivilegeTool()
+
+	var prefix []string
+	switch tool {
+	case "sudo":
+		if useAskpass() {
+			prefix = []string{"-A"}
+		}
+	case "run0":
+		// run0 no hereda el directorio ni la terminal sin estas opciones
+		prefix = []string{"--background=", "--chdir=" + mustGetwd()}
+	}
+
+	fullArgs := append(prefix, name)
+	return tool, append(fullArgs, args...)
+}
+
+func mustGetwd() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "/"
+	}
+	return dir
+}
+
+// PrivilegesCached indica si se puede elevar privilegios sin pedir contraseña
+func PrivilegesCached() bool {
+	if IsRoot() {
+		return true
+	}
+
+	switch PrivilegeTool() {
+	case "sudo":
+		_, err := RunCommandSilent("sudo", "-n", "true")
+		return err == nil
+	case "doas":
+		_, err := RunCommandSilent("doas", "-n", "true")
+		return err == nil
+	default:
+		// run0 pregunta a polkit en cada ejecución
+		return false
+	}
+}
+
+// EnsurePrivileges pide la contraseña una sola vez al inicio de una instalación
+// y mantiene vivo el ticket de sudo hasta que termine el proceso, para que las
+// compilaciones largas de AUR no vuelvan a pedirla a mitad de camino
+func EnsurePrivileges() error {
+	if IsRoot() {
+		return nil
+	}
+
+	tool := PrivilegeTool()
+	if tool == "" {
+		return errors.New(i18n.T("utils.no_privilege_tool"))
+	}
+
+	switch tool {
+	case "sudo":
+		args := []string{"-v"}
+		if useAskpass() {
+			args = []string{"-A", "-v"}
+		}
+		if err := RunCommand("sudo", args...); err != nil {
+			return fmt.Errorf(i18n.T("utils.sudo_failed"), err)
+		}
+		startKeepalive(func() {
+			exec.Command("sudo", "-n", "-v").Run()
+		})
+	case "doas":
+		// Con "persist" en doas.conf esto deja la autorización en caché. No hay
+		// keepalive: a diferencia de sudo -v, usar doas no extiende "persist",
+		// que vence a los 5 minutos de autenticarse y vuelve a pedir la contraseña
+		if err := RunCommand("doas", "true"); err != nil {
+			return fmt.Errorf(i18n.T("utils.doas_failed"), err)
+		}
+	case "run0":
+		// run0 pregunta a polkit en cada ejecución: esta primera comprueba que el
+		// usuario pueda elevar antes de empezar y, si polkit usa auth_admin_keep,
+		// deja la autorización en caché por unos minutos
+		tool, args := privilegedArgs("true")
+		if err := RunCommand(tool, args...); err != nil {
+			return fmt.Errorf(i18n.T("utils.run0_failed"), err)
+		}
+	}
+
+	return nil
+}
+
+// startKeepalive ejecuta refresh periódicamente mientras orgmos siga en ejecución
+func startKeepalive(refresh func()) {
+	keepaliveOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(keepaliveInterval)
+			defer ticker.Stop()
+			for range ticker.C {
+				refresh()
+			}
+		}()
+	})
+}
@@ -0,0 +1,282 @@
+package utils
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"orgmos/internal/i18n"
+)
+
+// logsDirName es el subdirectorio del directorio de estado con los logs de cada corrida
+const logsDirName = "logs"
+
+// maxRunLogs es la cantidad de logs de corridas que se conservan
+const maxRunLogs = 50
+
+// logCaptureLimit es la salida máxima por flujo (stdout o stderr) que se guarda de un comando
+const logCaptureLimit = 1024 * 1024
+
+// RunHeader es la primera línea del log de una corrida
+type RunHeader struct {
+	Type    string    `json:"type"` // "run"
+	ID      string    `json:"id"`
+	Time    time.Time `json:"time"`
+	Version string    `json:"version"`
+	Args    []string  `json:"args"`
+	User    string    `json:"user,omitempty"`
+}
+
+// CommandRecord es una línea del log por cada comando ejecutado
+type CommandRecord struct {
+	Type       string    `json:"type"` // "command"
+	Time       time.Time `json:"time"`
+	Command    string    `json:"command"`
+	Args       []string  `json:"args"`
+	ExitCode   int       `json:"exit_code"` // -1 si no llegó a terminar normalmente
+	DurationMS int64     `json:"duration_ms"`
+	Stdout     string    `json:"stdout,omitempty"`
+	Stderr     string    `json:"stderr,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// runLog es el log de la corrida actual. El archivo se crea con el primer comando,
+// así las corridas que no ejecutan nada (ej: 'orgmos logs') no dejan log.
+var runLog struct {
+	mu     sync.Mutex
+	header *RunHeader
+	file   *os.File
+	failed bool
+}
+
+// StartRunLog prepara el log de la corrida actual en $XDG_STATE_HOME/orgmos/logs
+func StartRunLog(version string, args []string) {
+	runLog.mu.Lock()
+	defer runLog.mu.Unlock()
+
+	now := time.Now()
+	runLog.header = &RunHeader{
+		Type:    "run",
+		ID:      newRunID(now, os.Getpid()),
+		Time:    now,
+		Version: version,
+		Args:    args,
+		User:    GetTargetUser().Name,
+	}
+}
+
+// runIDLayout da IDs de ancho fijo con nanosegundos, así el orden alfabético
+// es cronológico aun entre corridas que empiezan en el mismo segundo
+const runIDLayout = "20060102-150405.000000000"
+
+// newRunID arma el ID de una corrida a partir de su hora de inicio y el PID
+func newRunID(now time.Time, pid int) string {
+	return fmt.Sprintf("%s-%d", now.UTC().Format(runIDLayout), pid)
+}
+
+// ValidRunID indica si id es un nombre de log válido. Rechaza separadores de
+// ruta y "..", para que un ID no pueda salir del directorio de logs.
+func ValidRunID(id string) bool {
+	return id != "" && id != "." && !strings.Contains(id, "..") &&
+		!strings.ContainsAny(id, `/\`)
+}
+
+// CurrentRunID retorna el ID de la corrida actual, o "" si no se inició el log
+func CurrentRunID() string {
+	runLog.mu.Lock()
+	defer runLog.mu.Unlock()
+
+	if runLog.header == nil {
+		return ""
+	}
+	return runLog.header.ID
+}
+
+// GetLogsDir obtiene el directorio de logs de corridas
+func GetLogsDir() string {
+	return filepath.Join(GetOrgmosStateDir(), logsDirName)
+}
+
+// RunLogPath retorna la ruta del log de una corrida
+func RunLogPath(id string) string {
+	return filepath.Join(GetLogsDir(), id+".jsonl")
+}
+
+// writeLogLine agrega una línea al log, creándolo si hace falta.
+// Los errores de escritura desactivan el log sin interrumpir la corrida.
+func writeLogLine(record CommandRecord) {
+	runLog.mu.Lock()
+	defer runLog.mu.Unlock()
+
+	if runLog.header == nil || runLog.failed {
+		return
+	}
+
+	if runLog.file == nil {
+		file, err := openRunLog(runLog.header)
+		if err != nil {
+			runLog.failed = true
+			return
+		}
+		runLog.file = file
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	runLog.file.Write(append(data, '\n'))
+}
+
+// openRunLog crea el archivo de log con su encabezado y borra los logs más viejos
+func openRunLog(header *RunHeader) (*os.File, error) {
+	if err := MkdirAllForUser(GetLogsDir(), 0755); err != nil {
+		return nil, err
+	}
+	pruneRunLogs(maxRunLogs - 1)
+
+	path := RunLogPath(header.ID)
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	ChownToUser(path)
+
+	data, err := json.Marshal(header)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
+// pruneRunLogs deja solo los keep logs más recientes
+func pruneRunLogs(keep int) {
+	ids, err := ListRunLogs()
+	if err != nil || len(ids) <= keep {
+		return
+	}
+	for _, id := range ids[keep:] {
+		os.Remove(RunLogPath(id))
+	}
+}
+
+// ListRunLogs retorna los IDs de las corridas con log, de la más reciente a la más antigua
+func ListRunLogs() ([]string, error) {
+	entries, err := os.ReadDir(GetLogsDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		if id, ok := strings.CutSuffix(entry.Name(), ".jsonl"); ok && !entry.IsDir() {
+			ids = append(ids, id)
+		}
+	}
+	// Los IDs empiezan con la fecha en ancho fijo, así que el orden alfabético es cronológico
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	return ids, nil
+}
+
+// ReadRunLog lee el log de una corrida. Las líneas dañadas se ignoran.
+func ReadRunLog(id string) (RunHeader, []CommandRecord, error) {
+	var header RunHeader
+	var records []CommandRecord
+
+	if !ValidRunID(id) {
+		return header, nil, fmt.Errorf(i18n.T("logs.invalid_run"), id)
+	}
+
+	file, err := os.Open(RunLogPath(id))
+	if err != nil {
+		return header, nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	// Cada línea puede llevar hasta dos flujos de logCaptureLimit
+	scanner.Buffer(make([]byte, 64*1024), 4*logCaptureLimit)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		var kind struct {
+			Type string `json:"type"`
+		}
+		if json.Unmarshal(line, &kind) != nil {
+			continue
+		}
+
+		switch kind.Type {
+		case "run":
+			json.Unmarshal(line, &header)
+		case "command":
+			var record CommandRecord
+			if json.Unmarshal(line, &record) == nil {
+				records = append(records, record)
+			}
+		}
+	}
+
+	return header, records, scanner.Err()
+}
+
+// transcript registra un comando en el log de la corrida: su salida se copia a
+// stdout/stderr y además se guarda (hasta logCaptureLimit por flujo)
+type transcript struct {
+	name   string
+	args   []string
+	start  time.Time
+	stdout tailBuffer
+	stderr tailBuffer
+}
+
+func newTranscript(name string, args []string) *transcript {
+	return &transcript{
+		name:   name,
+		args:   args,
+		start:  time.Now(),
+		stdout: tailBuffer{limit: logCaptureLimit},
+		stderr: tailBuffer{limit: logCaptureLimit},
+	}
+}
+
+// finish escribe el comando terminado en el log
+func (t *transcript) finish(err error) {
+	record := CommandRecord{
+		Type:       "command",
+		Time:       t.start,
+		Command:    t.name,
+		Args:       t.args,
+		DurationMS: time.Since(t.start).Milliseconds(),
+		Stdout:     t.stdout.String(),
+		Stderr:     t.stderr.String(),
+	}
+
+	if err != nil {
+		record.Error = err.Error()
+		record.ExitCode = -1
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			record.ExitCode = exitErr.ExitCode()
+		}
+	}
+
+	writeLogLine(record)
+}
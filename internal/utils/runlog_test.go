@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestNewRunIDSortsChronologically(t *testing.T) {
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	// Misma segunda, PIDs en orden inverso: el orden debe seguir la hora
+	ids := []string{
+		newRunID(base.Add(900*time.Millisecond), 100),
+		newRunID(base.Add(5*time.Millisecond), 99999),
+		newRunID(base.Add(time.Second), 1),
+		newRunID(base, 5000),
+	}
+	want := []string{ids[3], ids[1], ids[0], ids[2]}
+
+	sort.Strings(ids)
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("orden = %v, esperado %v", ids, want)
+		}
+	}
+}
+
+func TestValidRunID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{newRunID(time.Now(), 42), true},
+		{"20260301-100000-42", true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"../../etc/passwd", false},
+		{"sub/run", false},
+		{`sub\run`, false},
+	}
+	for _, tt := range tests {
+		if got := ValidRunID(tt.id); got != tt.want {
+			t.Errorf("ValidRunID(%q) = %v, esperado %v", tt.id, got, tt.want)
+		}
+	}
+}
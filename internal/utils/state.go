@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// lastConfigFile guarda la fecha del último 'orgmos config' exitoso
+const lastConfigFile = "last-config"
+
+// GetOrgmosStateDir obtiene el directorio de estado de orgmos
+// ($XDG_STATE_HOME/orgmos o ~/.local/state/orgmos)
+func GetOrgmosStateDir() string {
+	if stateHome := os.Getenv("XDG_STATE_HOME"); stateHome != "" {
+		return filepath.Join(stateHome, "orgmos")
+	}
+
+	homeDir, err := GetHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".local", "state", "orgmos")
+}
+
+// GetPywalColorsFile obtiene el archivo de colores que pywal genera con cada
+// wallpaper ($XDG_CACHE_HOME/wal/colors.json o ~/.cache/wal/colors.json)
+func GetPywalColorsFile() string {
+	if cacheHome := os.Getenv("XDG_CACHE_HOME"); cacheHome != "" {
+		return filepath.Join(cacheHome, "wal", "colors.json")
+	}
+
+	homeDir, err := GetHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".cache", "wal", "colors.json")
+}
+
+// MarkConfigApplied registra que las configuraciones se copiaron ahora
+func MarkConfigApplied() error {
+	stamp := time.Now().Format(time.RFC3339) + "\n"
+	return WriteFileForUser(filepath.Join(GetOrgmosStateDir(), lastConfigFile), []byte(stamp), 0644)
+}
+
+// LastConfigApplied retorna la fecha del último 'orgmos config' exitoso
+func LastConfigApplied() (time.Time, bool) {
+	data, err := os.ReadFile(filepath.Join(GetOrgmosStateDir(), lastConfigFile))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	stamp, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return stamp, true
+}
@@ -0,0 +1,212 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+)
+
+// TargetUser es el usuario para el que trabaja orgmos. Normalmente es el usuario
+// actual, pero si orgmos se ejecuta como root mediante sudo, doas o pkexec es el
+// usuario que lo invocó: sus archivos van a su home y le pertenecen a él.
+type TargetUser struct {
+	Name    string
+	HomeDir string
+	UID     int
+	GID     int
+	Groups  []uint32 // Grupos suplementarios, para ejecutar comandos como el usuario
+}
+
+var (
+	targetUser     TargetUser
+	targetUserOnce sync.Once
+)
+
+// GetTargetUser retorna el usuario efectivo (ver TargetUser)
+func GetTargetUser() TargetUser {
+	targetUserOnce.Do(func() {
+		targetUser = resolveTargetUser()
+	})
+	return targetUser
+}
+
+// resolveTargetUser obtiene el usuario que invocó a orgmos a partir de
+// SUDO_USER, DOAS_USER o PKEXEC_UID; si no, el usuario actual
+func resolveTargetUser() TargetUser {
+	if IsRoot() {
+		for _, name := range []string{os.Getenv("SUDO_USER"), os.Getenv("DOAS_USER")} {
+			if name == "" || name == "root" {
+				continue
+			}
+			if u, err := user.Lookup(name); err == nil {
+				return newTargetUser(u)
+			}
+		}
+
+		if uid := os.Getenv("PKEXEC_UID"); uid != "" && uid != "0" {
+			if u, err := user.LookupId(uid); err == nil {
+				return newTargetUser(u)
+			}
+		}
+	}
+
+	current := TargetUser{UID: os.Getuid(), GID: os.Getgid()}
+	if u, err := user.Current(); err == nil {
+		current.Name = u.Username
+		current.HomeDir = u.HomeDir
+	}
+	// Para el usuario actual se respeta $HOME, como os.UserHomeDir
+	if home, err := os.UserHomeDir(); err == nil {
+		current.HomeDir = home
+	}
+	return current
+}
+
+func newTargetUser(u *user.User) TargetUser {
+	uid, _ := strconv.Atoi(u.Uid)
+	gid, _ := strconv.Atoi(u.Gid)
+
+	target := TargetUser{Name: u.Username, HomeDir: u.HomeDir, UID: uid, GID: gid}
+	if ids, err := u.GroupIds(); err == nil {
+		for _, id := range ids {
+			if g, err := strconv.ParseUint(id, 10, 32); err == nil {
+				target.Groups = append(target.Groups, uint32(g))
+			}
+		}
+	}
+	return target
+}
+
+// ActingForUser indica si orgmos corre como root en nombre de otro usuario
+func ActingForUser() bool {
+	return IsRoot() && GetTargetUser().UID != 0
+}
+
+// GetHomeDir obtiene el home del usuario efectivo. Reemplaza a os.UserHomeDir,
+// que bajo sudo apuntaría a /root.
+func GetHomeDir() (string, error) {
+	home := GetTargetUser().HomeDir
+	if home == "" {
+		return os.UserHomeDir()
+	}
+	return home, nil
+}
+
+// ChownToUser devuelve la propiedad de las rutas al usuario efectivo.
+// No hace nada si orgmos no corre como root en nombre de otro usuario.
+func ChownToUser(paths ...string) error {
+	if !ActingForUser() {
+		return nil
+	}
+
+	target := GetTargetUser()
+	for _, path := range paths {
+		if err := os.Lchown(path, target.UID, target.GID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ChownTreeToUser devuelve la propiedad de un directorio y todo su contenido al usuario efectivo
+func ChownTreeToUser(root string) error {
+	if !ActingForUser() {
+		return nil
+	}
+
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return ChownToUser(path)
+	})
+}
+
+// MkdirAllForUser crea un directorio y sus padres; los que se crean quedan a
+// nombre del usuario efectivo
+func MkdirAllForUser(path string, perm os.FileMode) error {
+	// Buscar el primer ancestro existente para saber qué directorios son nuevos
+	var created []string
+	for dir := filepath.Clean(path); ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+		created = append(created, dir)
+		if dir == filepath.Dir(dir) {
+			break
+		}
+	}
+
+	if err := os.MkdirAll(path, perm); err != nil {
+		return err
+	}
+	return ChownToUser(created...)
+}
+
+// WriteFileForUser escribe un archivo (creando sus directorios) a nombre del usuario efectivo
+func WriteFileForUser(path string, data []byte, perm os.FileMode) error {
+	if err := MkdirAllForUser(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return err
+	}
+	return ChownToUser(path)
+}
+
+// UserCommand crea un comando ligado a ctx que se ejecuta como el usuario efectivo.
+// Se usa para git, makepkg y los helpers AUR, que no deben correr como root.
+func UserCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
+	return newUserCommand(ctx, true, name, args...)
+}
+
+// newUserCommand es como newCommand pero con las credenciales del usuario efectivo
+func newUserCommand(ctx context.Context, interactive bool, name string, args ...string) *exec.Cmd {
+	cmd := newCommand(ctx, interactive, name, args...)
+	if !ActingForUser() {
+		return cmd
+	}
+
+	target := GetTargetUser()
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Credential = &syscall.Credential{
+		Uid:    uint32(target.UID),
+		Gid:    uint32(target.GID),
+		Groups: target.Groups,
+	}
+	cmd.Env = append(os.Environ(),
+		"HOME="+target.HomeDir,
+		"USER="+target.Name,
+		"LOGNAME="+target.Name,
+	)
+	return cmd
+}
+
+// RunUserCommand es como RunCommand pero como el usuario efectivo
+func RunUserCommand(name string, args ...string) error {
+	return runInteractive(Context(), newUserCommand, name, args...)
+}
+
+// RunUserCommandCapture es como RunCommandCapture pero como el usuario efectivo
+func RunUserCommandCapture(name string, args ...string) (string, error) {
+	return runCapture(Context(), newUserCommand, nil, name, args...)
+}
+
+// RunUserCommandOutput es como RunCommandOutput pero como el usuario efectivo
+func RunUserCommandOutput(out io.Writer, name string, args ...string) (string, error) {
+	return runCapture(Context(), newUserCommand, out, name, args...)
+}
+
+// RunUserCommandSilent es como RunCommandSilent pero como el usuario efectivo
+func RunUserCommandSilent(name string, args ...string) (string, error) {
+	return runSilent(Context(), newUserCommand, name, args...)
+}